@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/git"
+	"github.com/crixuamg/pkg/linters"
+	"github.com/crixuamg/pkg/report"
+	"github.com/crixuamg/pkg/runner"
+)
+
+// runCheck implements the headless "check" subcommand used by the git hooks.
+// It exits non-zero when any linter reports issues.
+func runCheck(args []string) int {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	var (
+		staged  bool
+		against string
+		format  string
+		timeout time.Duration
+		profile string
+	)
+	fs.BoolVar(&staged, "staged", false, "Lint the staged (index) version of staged files")
+	fs.StringVar(&against, "against", "", "Lint files changed since the merge base with this ref, e.g. @{upstream}")
+	fs.StringVar(&format, "format", "text", "Output format: text, markdown or json")
+	fs.DurationVar(&timeout, "timeout", 5*time.Minute, "Maximum time to wait for the linters")
+	fs.StringVar(&profile, "profile", "", "Activate a profile of the configuration, e.g. ci")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	// Load configuration
+	cfg, err := loadProfile(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		return 1
+	}
+
+	root, err := config.FindGitRoot()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding repository root: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Collect the targets
+	targets := fs.Args()
+	planRoot := root
+	var mirror *git.Mirror
+	switch {
+	case staged:
+		files, err := git.StagedFiles(root)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing staged files: %v\n", err)
+			return 1
+		}
+		if len(files) == 0 {
+			return 0
+		}
+
+		// Lint the index version so partially staged files are checked as committed
+		mirror, err = git.NewIndexMirror(root, files)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error checking out staged files: %v\n", err)
+			return 1
+		}
+		defer mirror.Close()
+
+		targets = append(targets, mirror.Paths()...)
+		ctx = linters.WithWorkDir(ctx, mirror.Root)
+		planRoot = mirror.Root
+
+	case against != "":
+		ref := against
+		if ref == "@{upstream}" || ref == "@{u}" {
+			upstream, err := git.Upstream(root)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "lazylint: no upstream branch configured, skipping check")
+				return 0
+			}
+			ref = upstream
+		}
+
+		files, err := git.ChangedFiles(root, ref)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing files changed since %s: %v\n", ref, err)
+			return 1
+		}
+		if len(files) == 0 {
+			return 0
+		}
+		for _, file := range files {
+			targets = append(targets, filepath.Join(root, file))
+		}
+	}
+
+	registry := newRegistry(cfg)
+	ws := detectWorkspace(root, registry)
+	jobs := runner.Plan(cfg, ws, planRoot, registry.GetEnabled(cfg.LinterEnabled), targets)
+	results, runErr := runner.RunJobs(ctx, jobs)
+	if runErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", runErr)
+	}
+
+	// Report paths of the index mirror as repository paths
+	if mirror != nil {
+		for _, result := range results {
+			result.Output = mirror.MapOutput(result.Output)
+			result.Error = mirror.MapOutput(result.Error)
+			result.Dir = mirror.MapOutput(result.Dir)
+		}
+	}
+
+	rep := report.New(results, root)
+	switch format {
+	case "markdown":
+		opts, err := markdownOptions(cfg, root, "", "")
+		if err == nil {
+			err = rep.WriteMarkdown(os.Stdout, opts)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+			return 1
+		}
+	case "json":
+		if err := rep.WriteJSON(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+			return 1
+		}
+	default:
+		printText(rep)
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			return 1
+		}
+	}
+	if runErr != nil {
+		return 1
+	}
+	return 0
+}
+
+// printText prints the findings of a report in a compiler-like format
+func printText(rep *report.Report) {
+	for _, l := range rep.Linters {
+		// Show the raw output when nothing could be parsed from a failing linter
+		if !l.Success && len(l.Findings) == 0 {
+			fmt.Printf("=== %s ===\n%s%s\n", l.Name, l.Output, l.Stderr)
+		}
+	}
+
+	for _, f := range rep.Findings {
+		location := fmt.Sprintf("%s:%d", rep.RelPath(f.File), f.Line)
+		if f.Column > 0 {
+			location += fmt.Sprintf(":%d", f.Column)
+		}
+		fmt.Printf("%s: %s: %s [%s]\n", location, f.Severity, f.Message, f.Linter)
+	}
+
+	total, errors, warnings := rep.Totals()
+	if total > 0 {
+		fmt.Printf("\nlazylint: %d findings (%d errors, %d warnings)\n", total, errors, warnings)
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/tui"
+)
+
+// configUsage documents the "config" subcommand
+const configUsage = `Usage:
+  lazylint config show [--origin] [--config <file>] [--set key=value]
+  lazylint config validate [--config <file>] [--set key=value]
+  lazylint config schema`
+
+// runConfig implements the "config" subcommand
+func runConfig(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, configUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "show":
+		return runConfigShow(args[1:])
+	case "validate":
+		return runConfigValidate(args[1:])
+	case "schema":
+		return runConfigSchema()
+	}
+
+	fmt.Fprintln(os.Stderr, configUsage)
+	return 2
+}
+
+// runConfigShow prints the effective settings
+func runConfigShow(args []string) int {
+	fs := flag.NewFlagSet("config show", flag.ContinueOnError)
+	var (
+		origin     bool
+		configPath string
+		overrides  stringList
+	)
+	fs.BoolVar(&origin, "origin", false, "Show the layer that set each value")
+	fs.StringVar(&configPath, "config", "", "Path to configuration file")
+	fs.Var(&overrides, "set", "Override a setting, e.g. ui.theme=light, can be repeated")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	loaded, err := config.Load(config.LoadOptions{Path: configPath, Overrides: overrides})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		return 1
+	}
+
+	writeSettings(os.Stdout, loaded, origin)
+	return 0
+}
+
+// runConfigValidate checks every layer of the configuration against the
+// schema and the configured keys against the actions, printing the problems.
+// Only errors fail the command, warnings don't.
+func runConfigValidate(args []string) int {
+	fs := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	var (
+		configPath string
+		overrides  stringList
+	)
+	fs.StringVar(&configPath, "config", "", "Path to configuration file")
+	fs.Var(&overrides, "set", "Override a setting, e.g. ui.theme=light, can be repeated")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	loaded, err := config.Load(config.LoadOptions{Path: configPath, Overrides: overrides})
+	var invalid *config.ValidationError
+	if errors.As(err, &invalid) {
+		for _, problem := range invalid.Problems {
+			fmt.Println(problem)
+		}
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		return 1
+	}
+
+	for _, warning := range loaded.Warnings {
+		fmt.Println(warning)
+	}
+	if err := tui.ValidateKeys(loaded.Config, newRegistry(loaded.Config)); err != nil {
+		fmt.Printf("keys: %v\n", err)
+		return 1
+	}
+
+	if len(loaded.Warnings) == 0 {
+		fmt.Println("Configuration is valid")
+	}
+	return 0
+}
+
+// runConfigSchema prints the JSON Schema of lazylint.yaml, for editors to
+// validate and complete the config
+func runConfigSchema() int {
+	data, err := config.ConfigSchema().JSONSchema()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating schema: %v\n", err)
+		return 1
+	}
+	fmt.Println(string(data))
+	return 0
+}
+
+// writeSettings prints the effective settings sorted by key, optionally
+// followed by the layer that set them
+func writeSettings(w io.Writer, loaded *config.Loaded, origin bool) {
+	settings := loaded.Settings()
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, key := range keys {
+		line := key + "\t" + formatSetting(settings[key])
+		if origin {
+			line += "\t" + loaded.Origin(key).String()
+		}
+		fmt.Fprintln(tw, line)
+	}
+	tw.Flush()
+}
+
+// formatSetting renders a setting the way it would be written in YAML
+func formatSetting(value interface{}) string {
+	switch v := value.(type) {
+	case []string:
+		return "[" + strings.Join(v, ", ") + "]"
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatSetting(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case string:
+		if v == "" {
+			return `""`
+		}
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/doctor"
+)
+
+// runDoctor implements the "doctor" subcommand. It describes how each
+// linter is found and exits non-zero when an enabled linter can't run.
+func runDoctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	var profile string
+	fs.StringVar(&profile, "profile", "", "Activate a profile of the configuration, e.g. ci")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, err := loadProfile(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		return 1
+	}
+
+	root, err := config.FindGitRoot()
+	if err != nil {
+		if root, err = os.Getwd(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding repository root: %v\n", err)
+			return 1
+		}
+	}
+
+	registry := newRegistry(cfg)
+	ws := detectWorkspace(root, registry)
+	checks := doctor.Run(context.Background(), cfg, registry, ws, root)
+
+	for i, check := range checks {
+		if i > 0 {
+			fmt.Println()
+		}
+		mark := "ok"
+		if !check.Usable() {
+			mark = "not usable"
+		}
+		fmt.Printf("%s: %s\n", check.Name, mark)
+		for _, line := range check.Describe() {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+
+	if doctor.Failed(checks) {
+		fmt.Fprintln(os.Stderr, "\nlazylint: some enabled linters can't run")
+		return 1
+	}
+	return 0
+}
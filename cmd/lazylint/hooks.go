@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/git"
+	"github.com/crixuamg/pkg/hooks"
+)
+
+// runHooks implements the "hooks" subcommand
+func runHooks(args []string) int {
+	if len(args) == 0 || (args[0] != "install" && args[0] != "uninstall") {
+		fmt.Fprintln(os.Stderr, "Usage: lazylint hooks install|uninstall")
+		return 2
+	}
+
+	root, err := config.FindGitRoot()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding repository root: %v\n", err)
+		return 1
+	}
+
+	// Honors core.hooksPath
+	dir, err := git.HooksDir(root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error locating the hooks directory: %v\n", err)
+		return 1
+	}
+
+	if args[0] == "uninstall" {
+		removed, err := hooks.Uninstall(dir, hooks.Default())
+		for _, path := range removed {
+			fmt.Printf("Removed %s\n", path)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if len(removed) == 0 {
+			fmt.Println("No LazyLint hooks installed")
+		}
+		return 0
+	}
+
+	installed, err := hooks.Install(dir, hookBinary(), hooks.Default())
+	for _, path := range installed {
+		fmt.Printf("Installed %s\n", path)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// hookBinary returns the command the hooks use to invoke LazyLint
+func hookBinary() string {
+	// Prefer the binary on the PATH so upgrades are picked up
+	if _, err := exec.LookPath("lazylint"); err == nil {
+		return "lazylint"
+	}
+	if exe, err := os.Executable(); err == nil {
+		return exe
+	}
+	return "lazylint"
+}
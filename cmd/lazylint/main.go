@@ -4,26 +4,50 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/crixuamg/pkg/config"
 	"github.com/crixuamg/pkg/linters"
 	"github.com/crixuamg/pkg/tui"
+	"github.com/crixuamg/pkg/workspace"
 )
 
 // Version information is defined in version.go
 
 func main() {
+	// Dispatch subcommands before parsing the TUI flags
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "report":
+			os.Exit(runReport(os.Args[2:]))
+		case "check":
+			os.Exit(runCheck(os.Args[2:]))
+		case "hooks":
+			os.Exit(runHooks(os.Args[2:]))
+		case "config":
+			os.Exit(runConfig(os.Args[2:]))
+		case "doctor":
+			os.Exit(runDoctor(os.Args[2:]))
+		}
+	}
+
 	// Parse command line flags
 	var (
-		target       string
+		targets      stringList
+		run          bool
 		configPath   string
+		overrides    stringList
+		profile      string
 		createConfig bool
 		showVersion  bool
 	)
 
-	flag.StringVar(&target, "target", "", "Target file or directory to analyze")
+	flag.Var(&targets, "target", "Target file or directory to analyze, can be repeated")
+	flag.BoolVar(&run, "run", false, "Run all available linters on the targets on start")
 	flag.StringVar(&configPath, "config", "", "Path to configuration file")
+	flag.Var(&overrides, "set", "Override a setting, e.g. ui.theme=light, can be repeated")
+	flag.StringVar(&profile, "profile", "", "Activate a profile of the configuration, e.g. quick")
 	flag.BoolVar(&createConfig, "create-config", false, "Create a default configuration file")
 	flag.BoolVar(&showVersion, "version", false, "Show version information")
 	flag.Parse()
@@ -52,27 +76,54 @@ func main() {
 	}
 
 	// Load configuration
-	cfg, err := config.LoadConfig()
+	if profile != "" {
+		overrides = append(overrides, "profile="+profile)
+	}
+	loaded, err := config.Load(config.LoadOptions{Path: configPath, Overrides: overrides})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
 	}
+	cfg := loaded.Config
 
-	// Create linter registry
-	registry := linters.DefaultRegistry()
+	// Unknown keys don't stop LazyLint but are worth a look
+	for _, warning := range loaded.Warnings {
+		fmt.Fprintln(os.Stderr, warning)
+	}
 
-	// Configure linters from config
-	for name, options := range cfg.Linters {
-		linter, ok := registry.Get(name)
-		if ok {
-			linter.Configure(options)
-		}
+	// Create linter registry, with the linters of the sub-projects
+	registry := newRegistry(cfg)
+	root, _ := config.FindGitRoot()
+	ws := detectWorkspace(root, registry)
+
+	// Reject unknown actions and conflicting keys before starting the UI
+	if err := tui.ValidateKeys(cfg, registry); err != nil {
+		fmt.Fprintf(os.Stderr, "Error in configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Preselect the targets in the explorer, running without targets lints
+	// the current directory
+	if run && len(targets) == 0 {
+		targets = stringList{"."}
+	}
+	model := tui.NewModel(cfg, registry)
+	model.SetConfigPath(loaded.ProjectPath)
+	model.SetWorkspace(ws)
+	model.WatchConfig(config.LoadOptions{Path: configPath, Overrides: overrides}, loaded.LayerFiles)
+	if err := model.SetTargets(targets, run); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if n := len(loaded.Warnings); n > 0 {
+		model.SetStatus(fmt.Sprintf("%d configuration warning(s), run lazylint config validate", n))
 	}
 
 	// Create and start the Bubble Tea program
 	p := tea.NewProgram(
-		tui.NewModel(cfg, registry),
+		model,
 		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(),
 	)
 
 	if _, err := p.Run(); err != nil {
@@ -80,3 +131,57 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// stringList is a flag that can be given several times
+type stringList []string
+
+// String returns the values joined by commas
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set adds a value
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// newRegistry creates the default linter registry configured from cfg
+func newRegistry(cfg *config.Config) *linters.Registry {
+	registry := linters.DefaultRegistry()
+
+	// Configure linters from config and its active profile
+	for _, linter := range registry.GetAll() {
+		linter.Configure(cfg.LinterOptions(linter.Name()))
+	}
+
+	return registry
+}
+
+// detectWorkspace scans the repository at root for sub-projects and lets the
+// linters of the registry run from them. It returns nil when the scan fails.
+func detectWorkspace(root string, registry *linters.Registry) *workspace.Workspace {
+	if root == "" {
+		return nil
+	}
+	ws, err := workspace.Detect(root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to detect projects: %v\n", err)
+		return nil
+	}
+	registry.SetDirs(ws.Dirs())
+	return ws
+}
+
+// loadProfile loads the configuration with the given profile active, if any
+func loadProfile(profile string) (*config.Config, error) {
+	var overrides []string
+	if profile != "" {
+		overrides = append(overrides, "profile="+profile)
+	}
+	loaded, err := config.Load(config.LoadOptions{Overrides: overrides})
+	if err != nil {
+		return nil, err
+	}
+	return loaded.Config, nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/git"
+	"github.com/crixuamg/pkg/report"
+	"github.com/crixuamg/pkg/runner"
+)
+
+// runReport implements the "report" subcommand and returns the exit code
+func runReport(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	var (
+		htmlOut      string
+		format       string
+		output       string
+		baseline     string
+		linkTemplate string
+		target       string
+		timeout      time.Duration
+		profile      string
+	)
+	fs.StringVar(&htmlOut, "html", "", "Write a self-contained HTML report to this file or directory")
+	fs.StringVar(&format, "format", "", "Report format: html, markdown or json")
+	fs.StringVar(&output, "output", "", "Write the report to this file instead of stdout")
+	fs.StringVar(&baseline, "baseline", "", "JSON report of a previous run to compare against")
+	fs.StringVar(&linkTemplate, "link-template", "", "Link template for markdown findings, e.g. https://host/repo/blob/{commit}/{path}#L{line}")
+	fs.StringVar(&target, "target", "", "Target file or directory to analyze")
+	fs.DurationVar(&timeout, "timeout", 5*time.Minute, "Maximum time to wait for the linters")
+	fs.StringVar(&profile, "profile", "", "Activate a profile of the configuration, e.g. ci")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	// --html implies the HTML format
+	if format == "" {
+		format = "markdown"
+		if htmlOut != "" {
+			format = "html"
+		}
+	}
+	if format == "html" && htmlOut == "" {
+		htmlOut = output
+	}
+	if format == "html" && htmlOut == "" {
+		fmt.Fprintln(os.Stderr, "Error: the HTML format needs an output location, use --html <dir>")
+		return 2
+	}
+	if format != "html" && format != "markdown" && format != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q\n", format)
+		return 2
+	}
+
+	// Load configuration
+	cfg, err := loadProfile(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		return 1
+	}
+
+	// Run all available linters
+	var targets []string
+	if target != "" {
+		targets = append(targets, target)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	root, _ := config.FindGitRoot()
+	registry := newRegistry(cfg)
+	ws := detectWorkspace(root, registry)
+	results, err := runner.RunJobs(ctx, runner.Plan(cfg, ws, root, registry.GetEnabled(cfg.LinterEnabled), targets))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	rep := report.New(results, root)
+
+	if format == "html" {
+		path, err := rep.WriteHTMLFile(htmlOut, activeTheme(cfg))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+			return 1
+		}
+
+		total, errors, warnings := rep.Totals()
+		fmt.Printf("Wrote %s (%d findings: %d errors, %d warnings)\n", path, total, errors, warnings)
+		return 0
+	}
+
+	// Text formats go to stdout unless an output file is given
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating %s: %v\n", output, err)
+			return 1
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if format == "json" {
+		err = rep.WriteJSON(w)
+	} else {
+		opts, optsErr := markdownOptions(cfg, root, linkTemplate, baseline)
+		if optsErr != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", optsErr)
+			return 1
+		}
+		err = rep.WriteMarkdown(w, opts)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// markdownOptions builds the markdown options from the configuration and flags
+func markdownOptions(cfg *config.Config, root, linkTemplate, baseline string) (report.MarkdownOptions, error) {
+	opts := report.MarkdownOptions{
+		LinkTemplate: cfg.Report.LinkTemplate,
+	}
+	if linkTemplate != "" {
+		opts.LinkTemplate = linkTemplate
+	}
+	opts.Commit, _ = git.HeadCommit(root)
+
+	if baseline != "" {
+		findings, err := report.LoadBaseline(baseline)
+		if err != nil {
+			return opts, err
+		}
+		opts.Baseline = findings
+	}
+
+	return opts, nil
+}
+
+// activeTheme returns the configured theme, falling back to the default theme
+func activeTheme(cfg *config.Config) config.ThemeConfig {
+	if theme, ok := cfg.UI.Themes[cfg.UI.Theme]; ok {
+		return theme
+	}
+	defaults := config.DefaultConfig()
+	return defaults.UI.Themes[defaults.UI.Theme]
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 // ThemeColors holds the colors for a theme
@@ -29,14 +31,44 @@ type ThemeConfig struct {
 
 // UIConfig holds the UI configuration
 type UIConfig struct {
-	Theme   string                 `mapstructure:"theme"`
-	Themes  map[string]ThemeConfig `mapstructure:"themes"`
+	Theme  string                 `mapstructure:"theme"`
+	Themes map[string]ThemeConfig `mapstructure:"themes"`
+
+	// Layout is "tabs" or "panes", the lazygit-style side-by-side layout
+	Layout string `mapstructure:"layout"`
+
+	// Split is the width of the left column of the panes layout, in percent
+	Split int `mapstructure:"split"`
+}
+
+// Layouts of the UI
+const (
+	LayoutTabs  = "tabs"
+	LayoutPanes = "panes"
+)
+
+// ReportConfig holds the report configuration
+type ReportConfig struct {
+	// LinkTemplate builds links to findings in markdown reports, e.g.
+	// "https://gitlab.com/group/project/-/blob/{commit}/{path}#L{line}"
+	LinkTemplate string `mapstructure:"link_template"`
 }
 
 // Config holds the application configuration
 type Config struct {
 	Linters map[string]map[string]interface{} `mapstructure:"linters"`
-	UI      UIConfig                         `mapstructure:"ui"`
+	UI      UIConfig                          `mapstructure:"ui"`
+	Report  ReportConfig                      `mapstructure:"report"`
+
+	// Keys maps action names to the keys that trigger them, replacing the defaults
+	Keys map[string][]string `mapstructure:"keys"`
+
+	// Profile is the active profile, empty for none
+	Profile  string             `mapstructure:"profile"`
+	Profiles map[string]Profile `mapstructure:"profiles"`
+
+	// Overrides adjust the linter options per directory of the repository
+	Overrides []Override `mapstructure:"overrides"`
 }
 
 // DefaultConfig returns the default configuration
@@ -69,8 +101,33 @@ func DefaultConfig() *Config {
 				"enabled": true,
 			},
 		},
+		Profiles: map[string]Profile{
+			"quick": {
+				Description: "Syntax checks only",
+				Enabled:     []string{"php", "eslint"},
+			},
+			"full": {
+				Description: "Every linter at its strictest level",
+				Enabled:     []string{"phpstan", "phpcs", "php", "golangci-lint", "eslint"},
+				Linters: map[string]map[string]interface{}{
+					"phpstan": {"args": []string{"analyse", "--level=max"}},
+					"phpcs":   {"args": []string{"--standard=PSR12", "--severity=1"}},
+					"eslint":  {"args": []string{"--format=stylish", "--max-warnings=0"}},
+				},
+			},
+			"ci": {
+				Description: "Every linter without colors or progress output",
+				Enabled:     []string{"phpstan", "phpcs", "php", "golangci-lint", "eslint"},
+				Linters: map[string]map[string]interface{}{
+					"phpstan":       {"args": []string{"analyse", "--level=5", "--no-progress"}},
+					"golangci-lint": {"args": []string{"run", "--out-format=line-number"}},
+				},
+			},
+		},
 		UI: UIConfig{
-			Theme: "tokyo-night",
+			Theme:  "tokyo-night",
+			Layout: LayoutTabs,
+			Split:  30,
 			Themes: map[string]ThemeConfig{
 				"tokyo-night": {
 					Name: "tokyo-night",
@@ -133,78 +190,182 @@ func DefaultConfig() *Config {
 	}
 }
 
-// LoadConfig loads the configuration from a file
-func LoadConfig() (*Config, error) {
-	config := DefaultConfig()
-
-	// Set up viper
-	v := viper.New()
-	v.SetConfigName("lazylint")
-	v.SetConfigType("yaml")
-
-	// Look for config in current directory and all parent directories
-	dir, err := os.Getwd()
+// LoadConfig loads the configuration from the default layers, see Load. The
+// file at path replaces the search for the project config when set.
+func LoadConfig(path string) (*Config, error) {
+	loaded, err := Load(LoadOptions{Path: path})
 	if err != nil {
-		return config, fmt.Errorf("failed to get current directory: %w", err)
+		return DefaultConfig(), err
 	}
+	return loaded.Config, nil
+}
 
-	// First check for user config in $HOME/.config/lazylint/
-	homeDir, err := os.UserHomeDir()
-	if err == nil {
-		userConfigDir := filepath.Join(homeDir, ".config", "lazylint")
-		v.AddConfigPath(userConfigDir)
-	}
+// configFileNames are the names of the project config file
+var configFileNames = []string{"lazylint.yaml", "lazylint.yml"}
 
-	// Then look in current directory and all parent directories
+// findConfigFile returns the nearest lazylint.yaml in dir and its parents
+func findConfigFile(dir string) string {
 	for {
-		v.AddConfigPath(dir)
-		if _, err := os.Stat(filepath.Join(dir, "lazylint.yaml")); err == nil {
-			break
-		}
-		if _, err := os.Stat(filepath.Join(dir, "lazylint.yml")); err == nil {
-			break
+		for _, name := range configFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
 		}
 
 		// Move to parent directory
 		parent := filepath.Dir(dir)
 		if parent == dir {
 			// We've reached the root directory
-			break
+			return ""
 		}
 		dir = parent
 	}
+}
 
-	// Try to read the config file
-	if err := v.ReadInConfig(); err != nil {
-		// It's okay if the config file doesn't exist
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return config, fmt.Errorf("failed to read config file: %w", err)
-		}
-	} else {
-		// Config file found, unmarshal it
-		if err := v.Unmarshal(config); err != nil {
-			return config, fmt.Errorf("failed to unmarshal config: %w", err)
-		}
+// mergeConfigFile merges the YAML file at path into v
+func mergeConfigFile(v *viper.Viper, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := v.MergeConfig(file); err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// UserConfigPath returns the path of the user config written by SaveUserSetting
+func UserConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "lazylint", "config.yaml"), nil
+}
+
+// SaveUserSetting stores a single setting, e.g. "ui.layout", in the user
+// config. The rest of the file, including its comments, is left as it is.
+func SaveUserSetting(key string, value interface{}) error {
+	path, err := UserConfigPath()
+	if err != nil {
+		return err
+	}
+	return SaveSettings(path, map[string]interface{}{key: value})
+}
+
+// MarshalSettings renders the config file at path with the settings, by
+// dotted keys such as "linters.phpstan.enabled", set in its current
+// contents. Only those keys change, comments and the order of the other
+// keys are kept. It returns the current contents too, empty for a missing file.
+func MarshalSettings(path string, settings map[string]interface{}) (current, updated []byte, err error) {
+	current, err = os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	updated, err = setYAMLValues(current, settings)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update config file %s: %w", path, err)
 	}
+	return current, updated, nil
+}
 
-	return config, nil
+// SaveSettings writes the settings into the config file at path, keeping the
+// other settings of the file, see MarshalSettings
+func SaveSettings(path string, settings map[string]interface{}) error {
+	_, data, err := MarshalSettings(path, settings)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
 }
 
 // SaveConfig saves the configuration to a file
 func SaveConfig(config *Config, path string) error {
+	data, err := MarshalConfig(config)
+	if err != nil {
+		return err
+	}
+
+	// Save the config
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalConfig renders the configuration as SaveConfig writes it
+func MarshalConfig(config *Config) ([]byte, error) {
 	v := viper.New()
-	v.SetConfigFile(path)
 
 	// Set the config values
 	v.Set("linters", config.Linters)
-	v.Set("ui", config.UI)
 
-	// Save the config
-	if err := v.WriteConfig(); err != nil {
-		return fmt.Errorf("failed to write config: %w", err)
+	// Set the UI settings by their mapstructure keys, e.g. dim_text, which
+	// the YAML encoder would otherwise write as dimtext. Unset values are
+	// left out to keep their defaults.
+	ui := make(map[string]interface{})
+	flattenValue("", reflect.ValueOf(config.UI), ui)
+	for key, value := range ui {
+		if reflect.ValueOf(value).IsZero() {
+			continue
+		}
+		v.Set("ui."+key, value)
+	}
+	if config.Report.LinkTemplate != "" {
+		v.Set("report", map[string]interface{}{
+			"link_template": config.Report.LinkTemplate,
+		})
+	}
+	if len(config.Keys) > 0 {
+		v.Set("keys", config.Keys)
 	}
 
-	return nil
+	// Profiles are set by their mapstructure keys too
+	if config.Profile != "" {
+		v.Set("profile", config.Profile)
+	}
+	profiles := make(map[string]interface{})
+	flattenValue("", reflect.ValueOf(config.Profiles), profiles)
+	for key, value := range profiles {
+		if reflect.ValueOf(value).IsZero() {
+			continue
+		}
+		v.Set("profiles."+key, value)
+	}
+
+	// Overrides are a list, each entry written by its mapstructure keys
+	if len(config.Overrides) > 0 {
+		var overrides []map[string]interface{}
+		for _, override := range config.Overrides {
+			entry := map[string]interface{}{"paths": override.Paths}
+			if override.Dir != "" {
+				entry["dir"] = override.Dir
+			}
+			if len(override.Linters) > 0 {
+				entry["linters"] = override.Linters
+			}
+			overrides = append(overrides, entry)
+		}
+		v.Set("overrides", overrides)
+	}
+
+	data, err := yaml.Marshal(v.AllSettings())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	return data, nil
 }
 
 // SaveUserConfig saves the configuration to the user's config directory
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -8,26 +9,26 @@ import (
 
 func TestDefaultConfig(t *testing.T) {
 	cfg := DefaultConfig()
-	
+
 	if cfg == nil {
 		t.Fatal("DefaultConfig returned nil")
 	}
-	
+
 	// Check that PHPStan config is set
-	if cfg.PHPStan.Args == nil || len(cfg.PHPStan.Args) == 0 {
+	if args, _ := cfg.Linters["phpstan"]["args"].([]string); len(args) == 0 {
 		t.Error("PHPStan args should not be empty")
 	}
-	
-	if !cfg.PHPStan.Enabled {
+
+	if cfg.Linters["phpstan"]["enabled"] != true {
 		t.Error("PHPStan should be enabled by default")
 	}
-	
+
 	// Check that PHPCS config is set
-	if cfg.PHPCS.Args == nil || len(cfg.PHPCS.Args) == 0 {
+	if args, _ := cfg.Linters["phpcs"]["args"].([]string); len(args) == 0 {
 		t.Error("PHPCS args should not be empty")
 	}
-	
-	if !cfg.PHPCS.Enabled {
+
+	if cfg.Linters["phpcs"]["enabled"] != true {
 		t.Error("PHPCS should be enabled by default")
 	}
 }
@@ -39,75 +40,67 @@ func TestSaveAndLoadConfig(t *testing.T) {
 		t.Fatalf("Failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(tempDir)
-	
+
+	// Keep the user config out of the test
+	t.Setenv("HOME", tempDir)
+
 	// Create a test config
 	testConfig := &Config{
-		PHPStan: PHPStanConfig{
-			Path:    "/test/path/to/phpstan",
-			Args:    []string{"analyse", "--level=8"},
-			Enabled: true,
-		},
-		PHPCS: PHPCSConfig{
-			Path:    "/test/path/to/phpcs",
-			Args:    []string{"--standard=PSR2"},
-			Enabled: false,
+		Linters: map[string]map[string]interface{}{
+			"phpstan": {
+				"path":    "/test/path/to/phpstan",
+				"args":    []string{"analyse", "--level=8"},
+				"enabled": true,
+			},
+			"phpcs": {
+				"path":    "/test/path/to/phpcs",
+				"args":    []string{"--standard=PSR2"},
+				"enabled": false,
+			},
 		},
 	}
-	
+
 	// Save the config
-	configPath := filepath.Join(tempDir, "crixuamg.yaml")
+	configPath := filepath.Join(tempDir, "lazylint.yaml")
 	err = SaveConfig(testConfig, configPath)
 	if err != nil {
 		t.Fatalf("Failed to save config: %v", err)
 	}
-	
+
 	// Check that the file exists
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		t.Fatalf("Config file was not created at %s", configPath)
 	}
-	
+
 	// Set up the environment to load from the temp directory
 	oldWd, err := os.Getwd()
 	if err != nil {
 		t.Fatalf("Failed to get current directory: %v", err)
 	}
-	
+
 	err = os.Chdir(tempDir)
 	if err != nil {
 		t.Fatalf("Failed to change directory: %v", err)
 	}
 	defer os.Chdir(oldWd)
-	
+
 	// Load the config
-	loadedConfig, err := LoadConfig()
+	loadedConfig, err := LoadConfig("")
 	if err != nil {
 		t.Fatalf("Failed to load config: %v", err)
 	}
-	
+
 	// Verify the loaded config matches what we saved
-	if loadedConfig.PHPStan.Path != testConfig.PHPStan.Path {
-		t.Errorf("PHPStan path mismatch: got %s, want %s", loadedConfig.PHPStan.Path, testConfig.PHPStan.Path)
-	}
-	
-	if len(loadedConfig.PHPStan.Args) != len(testConfig.PHPStan.Args) {
-		t.Errorf("PHPStan args length mismatch: got %d, want %d", len(loadedConfig.PHPStan.Args), len(testConfig.PHPStan.Args))
-	} else {
-		for i, arg := range testConfig.PHPStan.Args {
-			if loadedConfig.PHPStan.Args[i] != arg {
-				t.Errorf("PHPStan arg mismatch at index %d: got %s, want %s", i, loadedConfig.PHPStan.Args[i], arg)
-			}
+	for _, name := range []string{"phpstan", "phpcs"} {
+		loaded, saved := loadedConfig.Linters[name], testConfig.Linters[name]
+		if loaded["path"] != saved["path"] {
+			t.Errorf("%s path mismatch: got %v, want %v", name, loaded["path"], saved["path"])
+		}
+		if fmt.Sprint(loaded["args"]) != fmt.Sprint(saved["args"]) {
+			t.Errorf("%s args mismatch: got %v, want %v", name, loaded["args"], saved["args"])
+		}
+		if loaded["enabled"] != saved["enabled"] {
+			t.Errorf("%s enabled mismatch: got %v, want %v", name, loaded["enabled"], saved["enabled"])
 		}
-	}
-	
-	if loadedConfig.PHPStan.Enabled != testConfig.PHPStan.Enabled {
-		t.Errorf("PHPStan enabled mismatch: got %t, want %t", loadedConfig.PHPStan.Enabled, testConfig.PHPStan.Enabled)
-	}
-	
-	if loadedConfig.PHPCS.Path != testConfig.PHPCS.Path {
-		t.Errorf("PHPCS path mismatch: got %s, want %s", loadedConfig.PHPCS.Path, testConfig.PHPCS.Path)
-	}
-	
-	if loadedConfig.PHPCS.Enabled != testConfig.PHPCS.Enabled {
-		t.Errorf("PHPCS enabled mismatch: got %t, want %t", loadedConfig.PHPCS.Enabled, testConfig.PHPCS.Enabled)
 	}
 }
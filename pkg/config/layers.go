@@ -0,0 +1,330 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Layers of the configuration, from the lowest to the highest precedence
+const (
+	LayerDefault = "default"
+	LayerUser    = "user"
+	LayerProject = "project"
+	LayerLocal   = "local"
+	LayerEnv     = "env"
+	LayerFlag    = "flag"
+)
+
+// LocalConfigName is the name of the personal override of the project
+// config, meant to be gitignored
+const LocalConfigName = "lazylint.local.yaml"
+
+// EnvPrefix starts the environment variables that override settings, e.g.
+// LAZYLINT_UI_THEME for ui.theme
+const EnvPrefix = "LAZYLINT_"
+
+// Origin tells which layer set a value, and the file, environment variable
+// or flag it came from
+type Origin struct {
+	Layer  string
+	Source string
+}
+
+// String describes the origin, e.g. "project (/repo/lazylint.yaml)"
+func (o Origin) String() string {
+	if o.Source == "" {
+		return o.Layer
+	}
+	return fmt.Sprintf("%s (%s)", o.Layer, o.Source)
+}
+
+// LoadOptions selects the files and overrides Load reads
+type LoadOptions struct {
+	// Path replaces the search for the project config when set
+	Path string
+
+	// Overrides are "key=value" settings given on the command line
+	Overrides []string
+}
+
+// Loaded is a configuration along with the origin of its settings
+type Loaded struct {
+	Config *Config
+
+	// Origins maps dotted keys such as "ui.theme" to the layer that set them.
+	// Keys missing from the map have their default value.
+	Origins map[string]Origin
+
+	// ProjectPath is the project config file, empty when there is none
+	ProjectPath string
+
+	// Files are the config files that were read, from the lowest precedence
+	Files []string
+
+	// LayerFiles are the config files of every layer, including the ones
+	// that don't exist yet, to watch for changes
+	LayerFiles []string
+
+	// Warnings are the problems found that don't stop LazyLint, such as
+	// unknown keys
+	Warnings []Problem
+}
+
+// Origin returns the origin of the setting with the given key
+func (l *Loaded) Origin(key string) Origin {
+	if origin, ok := l.Origins[key]; ok {
+		return origin
+	}
+	return Origin{Layer: LayerDefault}
+}
+
+// Settings returns the effective value of every setting by dotted key
+func (l *Loaded) Settings() map[string]interface{} {
+	settings := make(map[string]interface{})
+	flattenValue("", reflect.ValueOf(l.Config), settings)
+	return settings
+}
+
+// layerFile is a config file read as part of a layer
+type layerFile struct {
+	layer string
+	path  string
+}
+
+// Load reads the configuration layer by layer, each overriding the ones
+// before it: the built-in defaults, the user config in ~/.config/lazylint,
+// the project lazylint.yaml, the lazylint.local.yaml next to it, LAZYLINT_*
+// environment variables and finally the overrides given on the command line.
+func Load(opts LoadOptions) (*Loaded, error) {
+	config := DefaultConfig()
+	origins := make(map[string]Origin)
+
+	files, err := layerFiles(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the defaults with viper, so that files setting some options of
+	// a linter or theme keep the defaults of the others
+	defaults := make(map[string]interface{})
+	flattenValue("", reflect.ValueOf(config), defaults)
+	v := viper.New()
+	v.SetConfigType("yaml")
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
+
+	// Merge the config files, checking them against the schema first
+	var (
+		problems []Problem
+		read     []string
+	)
+	for _, file := range files {
+		fileProblems, err := ValidateFile(file.path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, fileProblems...)
+
+		settings, err := readConfigFile(file.path)
+		if err != nil {
+			return nil, err
+		}
+		if err := v.MergeConfigMap(settings); err != nil {
+			return nil, fmt.Errorf("failed to merge config file %s: %w", file.path, err)
+		}
+		read = append(read, file.path)
+		recordOrigins(settings, Origin{Layer: file.layer, Source: file.path}, origins)
+	}
+
+	// Environment variables can set any key known from the defaults or the files
+	known := make(map[string]interface{})
+	flattenValue("", reflect.ValueOf(v.AllSettings()), known)
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		if !strings.HasPrefix(name, EnvPrefix) {
+			continue
+		}
+		if key := envKey(name, known); key != "" {
+			problems = append(problems, validateOverride(name, key, parseValue(value))...)
+			v.Set(key, parseValue(value))
+			origins[key] = Origin{Layer: LayerEnv, Source: name}
+		}
+	}
+
+	// Command line overrides come last
+	for _, override := range opts.Overrides {
+		key, value, ok := strings.Cut(override, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid setting %q, expected key=value", override)
+		}
+		problems = append(problems, validateOverride("--set "+key, key, parseValue(value))...)
+		v.Set(key, parseValue(value))
+		origins[key] = Origin{Layer: LayerFlag, Source: "--set " + key}
+	}
+
+	if HasErrors(problems) {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	if err := v.Unmarshal(config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	// The active profile must be one of the merged profiles
+	if profileProblems := checkProfile(config, origins["profile"]); len(profileProblems) > 0 {
+		return nil, &ValidationError{Problems: append(problems, profileProblems...)}
+	}
+
+	loaded := &Loaded{Config: config, Origins: origins, Files: read, Warnings: problems}
+	for _, file := range files {
+		if file.layer == LayerProject {
+			loaded.ProjectPath = file.path
+		}
+		loaded.LayerFiles = append(loaded.LayerFiles, file.path)
+	}
+	return loaded, nil
+}
+
+// layerFiles returns the config files of the user, project and local layers.
+// An explicit path replaces the search for the project config and must exist.
+func layerFiles(path string) ([]layerFile, error) {
+	var files []layerFile
+
+	// The user config directory may hold a lazylint.yaml too, which is read
+	// before the settings saved by the UI
+	if userPath, err := UserConfigPath(); err == nil {
+		files = append(files,
+			layerFile{LayerUser, filepath.Join(filepath.Dir(userPath), "lazylint.yaml")},
+			layerFile{LayerUser, userPath},
+		)
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	if path != "" {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("config file %s does not exist", path)
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("config file %s is a directory", path)
+		}
+	} else {
+		path = findConfigFile(dir)
+	}
+
+	// The local override sits next to the project config
+	if path != "" {
+		files = append(files, layerFile{LayerProject, path})
+		dir = filepath.Dir(path)
+	}
+	files = append(files, layerFile{LayerLocal, filepath.Join(dir, LocalConfigName)})
+
+	return files, nil
+}
+
+// readConfigFile reads the settings of a YAML config file, with lower-cased keys
+func readConfigFile(path string) (map[string]interface{}, error) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := mergeConfigFile(v, path); err != nil {
+		return nil, err
+	}
+	return v.AllSettings(), nil
+}
+
+// recordOrigins sets the origin of every value in settings
+func recordOrigins(settings map[string]interface{}, origin Origin, origins map[string]Origin) {
+	values := make(map[string]interface{})
+	flattenValue("", reflect.ValueOf(settings), values)
+	for key := range values {
+		origins[key] = origin
+	}
+}
+
+// flattenValue adds the leaves of value to out by dotted key. Structs are
+// keyed by their mapstructure tags; lists are leaves.
+func flattenValue(prefix string, value reflect.Value, out map[string]interface{}) {
+	join := func(name string) string {
+		if prefix == "" {
+			return name
+		}
+		return prefix + "." + name
+	}
+
+	switch value.Kind() {
+	case reflect.Invalid:
+		return
+	case reflect.Interface, reflect.Pointer:
+		if !value.IsNil() {
+			flattenValue(prefix, value.Elem(), out)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Tag.Get("mapstructure")
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+			flattenValue(join(name), value.Field(i), out)
+		}
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			flattenValue(join(fmt.Sprint(key.Interface())), value.MapIndex(key), out)
+		}
+	default:
+		if prefix != "" {
+			out[prefix] = value.Interface()
+		}
+	}
+}
+
+// EnvName returns the environment variable that overrides the setting with
+// the given key, e.g. LAZYLINT_LINTERS_GOLANGCI_LINT_ENABLED
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// envKey returns the known key set by the environment variable, if any
+func envKey(name string, known map[string]interface{}) string {
+	keys := make([]string, 0, len(known))
+	for key := range known {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if EnvName(key) == name {
+			return key
+		}
+	}
+	return ""
+}
+
+// parseValue reads a value given as text, e.g. "true", "5" or "[a, b]",
+// as YAML, falling back to the text itself
+func parseValue(text string) interface{} {
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(text), &value); err != nil || value == nil {
+		return text
+	}
+	return value
+}
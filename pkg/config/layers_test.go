@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeConfig writes a config file, creating its directory
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestLoadLayers(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(project)
+
+	userPath := filepath.Join(home, ".config", "lazylint", "config.yaml")
+	projectPath := filepath.Join(project, "lazylint.yaml")
+	localPath := filepath.Join(project, LocalConfigName)
+
+	writeConfig(t, userPath, "ui:\n  theme: light\n  layout: panes\n  split: 25\n")
+	writeConfig(t, projectPath, "ui:\n  theme: catppuccin\nlinters:\n  phpstan:\n    args: [analyse, --level=8]\n")
+	writeConfig(t, localPath, "ui:\n  split: 40\n")
+	t.Setenv("LAZYLINT_LINTERS_GOLANGCI_LINT_ENABLED", "false")
+
+	loaded, err := Load(LoadOptions{Overrides: []string{"ui.layout=tabs"}})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	cfg := loaded.Config
+	if cfg.UI.Theme != "catppuccin" || cfg.UI.Layout != "tabs" || cfg.UI.Split != 40 {
+		t.Errorf("UI = %+v, want catppuccin, tabs and 40", cfg.UI)
+	}
+	if cfg.Linters["golangci-lint"]["enabled"] != false {
+		t.Errorf("golangci-lint enabled = %v, want false", cfg.Linters["golangci-lint"]["enabled"])
+	}
+
+	// Setting some options of a linter keeps the defaults of the others
+	if cfg.Linters["phpstan"]["path"] != "phpstan" || cfg.Linters["phpstan"]["enabled"] != true {
+		t.Errorf("phpstan = %v, want the default path and enabled", cfg.Linters["phpstan"])
+	}
+
+	origins := map[string]Origin{
+		"ui.theme":                      {LayerProject, projectPath},
+		"ui.layout":                     {LayerFlag, "--set ui.layout"},
+		"ui.split":                      {LayerLocal, localPath},
+		"linters.phpstan.args":          {LayerProject, projectPath},
+		"linters.phpstan.path":          {LayerDefault, ""},
+		"linters.golangci-lint.enabled": {LayerEnv, "LAZYLINT_LINTERS_GOLANGCI_LINT_ENABLED"},
+	}
+	for key, want := range origins {
+		if got := loaded.Origin(key); got != want {
+			t.Errorf("Origin(%q) = %v, want %v", key, got, want)
+		}
+	}
+
+	if settings := loaded.Settings(); settings["ui.split"] != 40 {
+		t.Errorf("Settings()[ui.split] = %v, want 40", settings["ui.split"])
+	}
+}
+
+func TestLoadExplicitPath(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	if _, err := Load(LoadOptions{Path: filepath.Join(dir, "missing.yaml")}); err == nil {
+		t.Error("Expected an error for a missing config file")
+	}
+
+	path := filepath.Join(dir, "other", "custom.yaml")
+	writeConfig(t, path, "ui:\n  theme: light\n")
+	writeConfig(t, filepath.Join(dir, "lazylint.yaml"), "ui:\n  theme: catppuccin\n")
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.UI.Theme != "light" {
+		t.Errorf("Theme = %q, want the one of the explicit file", cfg.UI.Theme)
+	}
+}
+
+func TestLoadInvalidOverride(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	if _, err := Load(LoadOptions{Overrides: []string{"ui.theme"}}); err == nil {
+		t.Error("Expected an error for an override without a value")
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Override adjusts the options of linters for the files below some paths,
+// e.g. a service of a monorepo checked at its own PHPStan level
+type Override struct {
+	// Paths are globs relative to the repository root, e.g. "services/legacy/**"
+	Paths []string `mapstructure:"paths"`
+
+	// Dir is the working directory of the linters for these files, relative
+	// to the repository root. Empty uses the directory of the first path up
+	// to its first wildcard.
+	Dir string `mapstructure:"dir"`
+
+	// Linters overrides the options per linter
+	Linters map[string]map[string]interface{} `mapstructure:"linters"`
+}
+
+// Matches reports whether the slash-separated path relative to the
+// repository root is below one of the paths of the override. Paths without
+// wildcards name a file or a directory, which matches everything below it.
+func (o Override) Matches(rel string) bool {
+	for _, pattern := range o.Paths {
+		pattern = strings.Trim(path.Clean(filepath.ToSlash(pattern)), "/")
+		if strings.ContainsAny(pattern, "*?[") {
+			if MatchGlob(pattern, rel) {
+				return true
+			}
+			continue
+		}
+		if pattern == "." || pattern == "" || rel == pattern || strings.HasPrefix(rel, pattern+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkDir returns the working directory of the override relative to the
+// repository root, "." for the root itself
+func (o Override) WorkDir() string {
+	if o.Dir != "" {
+		return filepath.Clean(filepath.FromSlash(o.Dir))
+	}
+	if len(o.Paths) == 0 {
+		return "."
+	}
+
+	segments := strings.Split(strings.Trim(filepath.ToSlash(o.Paths[0]), "/"), "/")
+	dir := segments
+	for i, segment := range segments {
+		if strings.ContainsAny(segment, "*?[") {
+			dir = segments[:i]
+			break
+		}
+	}
+
+	// A path without wildcards may name a file, which runs in its directory
+	if len(dir) == len(segments) && strings.Contains(dir[len(dir)-1], ".") {
+		dir = dir[:len(dir)-1]
+	}
+	if len(dir) == 0 {
+		return "."
+	}
+	return filepath.Join(dir...)
+}
+
+// OverrideFor returns the index of the first override that configures the
+// linter for the slash-separated path relative to the repository root, or
+// -1 when the options of the linter apply as they are
+func (c *Config) OverrideFor(linter, rel string) int {
+	for i, override := range c.Overrides {
+		if _, ok := override.Linters[linter]; ok && override.Matches(rel) {
+			return i
+		}
+	}
+	return -1
+}
+
+// OverrideOptions returns the options of a linter with the active profile and
+// the override with the given index applied
+func (c *Config) OverrideOptions(linter string, index int) map[string]interface{} {
+	options := c.LinterOptions(linter)
+	for key, value := range c.Overrides[index].Linters[linter] {
+		options[key] = value
+	}
+	return options
+}
+
+// MatchGlob reports whether the slash-separated relative path matches the
+// pattern. Besides the filepath.Match syntax, a "**" segment matches any
+// number of directories.
+func MatchGlob(pattern, rel string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(rel, "/"))
+}
+
+// matchSegments matches path segments against pattern segments
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Collapse repeated "**" and try every possible split
+			for len(pattern) > 1 && pattern[1] == "**" {
+				pattern = pattern[1:]
+			}
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
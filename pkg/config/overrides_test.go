@@ -0,0 +1,95 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestOverrideWorkDir(t *testing.T) {
+	tests := []struct {
+		override Override
+		want     string
+	}{
+		{Override{Paths: []string{"services/legacy/**"}}, filepath.Join("services", "legacy")},
+		{Override{Paths: []string{"frontend/*/src/**"}}, "frontend"},
+		{Override{Paths: []string{"services/legacy"}}, filepath.Join("services", "legacy")},
+		{Override{Paths: []string{"scripts/deploy.php"}}, "scripts"},
+		{Override{Paths: []string{"**/*.php"}}, "."},
+		{Override{Paths: []string{"services/**"}, Dir: "services/new/"}, filepath.Join("services", "new")},
+	}
+	for _, tc := range tests {
+		if got := tc.override.WorkDir(); got != tc.want {
+			t.Errorf("WorkDir(%v) = %q, want %q", tc.override.Paths, got, tc.want)
+		}
+	}
+}
+
+func TestOverrideMatches(t *testing.T) {
+	tests := []struct {
+		paths []string
+		rel   string
+		want  bool
+	}{
+		{[]string{"services/legacy/**"}, "services/legacy/src/User.php", true},
+		{[]string{"services/legacy"}, "services/legacy/src/User.php", true},
+		{[]string{"services/legacy/"}, "services/legacy/User.php", true},
+		{[]string{"services/legacy"}, "services/legacy-v2/User.php", false},
+		{[]string{"scripts/deploy.php"}, "scripts/deploy.php", true},
+		{[]string{"scripts/deploy.php"}, "scripts/build.php", false},
+		{[]string{"services/*/User.php"}, "services/new/User.php", true},
+		{[]string{"services/*/User.php"}, "services/new/src/User.php", false},
+	}
+	for _, tc := range tests {
+		if got := (Override{Paths: tc.paths}).Matches(tc.rel); got != tc.want {
+			t.Errorf("Matches(%v, %s) = %t, want %t", tc.paths, tc.rel, got, tc.want)
+		}
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	writeConfig(t, filepath.Join(dir, "lazylint.yaml"), `overrides:
+  - paths: [services/legacy/**]
+    linters:
+      phpstan:
+        args: [analyse, --level=3]
+  - paths: [services/**]
+    linters:
+      phpstan:
+        args: [analyse, --level=8]
+`)
+
+	loaded, err := Load(LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.Warnings) > 0 {
+		t.Errorf("Unexpected warnings: %v", loaded.Warnings)
+	}
+	cfg := loaded.Config
+
+	tests := []struct {
+		linter, path string
+		want         int
+	}{
+		{"phpstan", "services/legacy/src/User.php", 0},
+		{"phpstan", "services/new/src/User.php", 1},
+		{"phpstan", "tools/build.php", -1},
+		{"phpcs", "services/legacy/src/User.php", -1},
+	}
+	for _, tc := range tests {
+		if got := cfg.OverrideFor(tc.linter, tc.path); got != tc.want {
+			t.Errorf("OverrideFor(%s, %s) = %d, want %d", tc.linter, tc.path, got, tc.want)
+		}
+	}
+
+	// The override replaces the args, keeping the other options
+	options := cfg.OverrideOptions("phpstan", 0)
+	if !reflect.DeepEqual(options["args"], []interface{}{"analyse", "--level=3"}) || options["path"] != "phpstan" {
+		t.Errorf("phpstan options = %v, want the level 3 args and the default path", options)
+	}
+}
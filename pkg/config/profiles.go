@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Profile is a named set of linter options, such as a quick syntax check or
+// a strict CI run, overriding the linters section while it's active
+type Profile struct {
+	Description string `mapstructure:"description"`
+
+	// Enabled lists the linters the profile runs, the others are disabled.
+	// Empty keeps the enabled flags of the linters section.
+	Enabled []string `mapstructure:"enabled"`
+
+	// Linters overrides the options of the linters section, per linter
+	Linters map[string]map[string]interface{} `mapstructure:"linters"`
+}
+
+// ProfileNames returns the names of the profiles in alphabetical order
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LinterOptions returns the options of a linter with the active profile
+// applied, to be passed to Configure
+func (c *Config) LinterOptions(name string) map[string]interface{} {
+	options := make(map[string]interface{})
+	for key, value := range c.Linters[name] {
+		options[key] = value
+	}
+
+	profile, ok := c.Profiles[c.Profile]
+	if !ok {
+		return options
+	}
+	for key, value := range profile.Linters[name] {
+		options[key] = value
+	}
+	if len(profile.Enabled) > 0 {
+		options["enabled"] = slices.Contains(profile.Enabled, name)
+	}
+	return options
+}
+
+// LinterEnabled reports whether a linter runs with the active profile.
+// Linters are enabled unless their options say otherwise.
+func (c *Config) LinterEnabled(name string) bool {
+	enabled, ok := c.LinterOptions(name)["enabled"].(bool)
+	return !ok || enabled
+}
+
+// checkProfile reports an active profile that isn't defined
+func checkProfile(config *Config, origin Origin) []Problem {
+	if config.Profile == "" {
+		return nil
+	}
+	if _, ok := config.Profiles[config.Profile]; ok {
+		return nil
+	}
+
+	source := origin.Source
+	if source == "" {
+		source = origin.Layer
+	}
+	return []Problem{{
+		Source:  source,
+		Key:     "profile",
+		Message: fmt.Sprintf("unknown profile %q, expected one of %s", config.Profile, strings.Join(config.ProfileNames(), ", ")),
+	}}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLinterOptions(t *testing.T) {
+	cfg := DefaultConfig()
+
+	// Without a profile the linters section applies
+	if got := cfg.LinterOptions("phpstan")["args"]; !reflect.DeepEqual(got, []string{"analyse", "--level=5"}) {
+		t.Errorf("phpstan args = %v, want the default ones", got)
+	}
+	if !cfg.LinterEnabled("phpstan") {
+		t.Error("Expected phpstan to be enabled without a profile")
+	}
+
+	// The quick profile only enables its linters
+	cfg.Profile = "quick"
+	if cfg.LinterEnabled("phpstan") || !cfg.LinterEnabled("php") || !cfg.LinterEnabled("eslint") {
+		t.Errorf("Expected only php and eslint in the quick profile")
+	}
+
+	// The full profile overrides the args, keeping the other options
+	cfg.Profile = "full"
+	options := cfg.LinterOptions("phpstan")
+	if !reflect.DeepEqual(options["args"], []string{"analyse", "--level=max"}) || options["path"] != "phpstan" {
+		t.Errorf("phpstan options = %v, want the strict args and the default path", options)
+	}
+
+	// Applying a profile leaves the linters section alone
+	if got := cfg.Linters["phpstan"]["args"]; !reflect.DeepEqual(got, []string{"analyse", "--level=5"}) {
+		t.Errorf("Linters section changed to %v", got)
+	}
+}
+
+func TestLoadProfiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	writeConfig(t, filepath.Join(dir, "lazylint.yaml"), `profile: legacy
+profiles:
+  legacy:
+    description: Old code
+    linters:
+      phpstan:
+        args: [analyse, --level=3]
+`)
+
+	loaded, err := Load(LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	cfg := loaded.Config
+	if got := cfg.LinterOptions("phpstan")["args"]; !reflect.DeepEqual(got, []interface{}{"analyse", "--level=3"}) {
+		t.Errorf("phpstan args = %v, want the ones of the legacy profile", got)
+	}
+	if _, ok := cfg.Profiles["quick"]; !ok {
+		t.Error("Expected the built-in profiles to be kept")
+	}
+
+	// The active profile must exist
+	_, err = Load(LoadOptions{Overrides: []string{"profile=strict"}})
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) || invalid.Problems[0].Key != "profile" {
+		t.Errorf("Load error = %v, want an unknown profile", err)
+	}
+}
@@ -0,0 +1,252 @@
+package config
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Schema describes the values allowed for a setting of lazylint.yaml
+type Schema struct {
+	// Type is "object", "array", "string", "boolean" or "integer"
+	Type string
+
+	// Description documents the setting in the JSON Schema
+	Description string
+
+	// Properties are the known keys of an object
+	Properties map[string]*Schema
+
+	// Additional is the schema of the values of other keys of an object, nil
+	// when other keys are unknown
+	Additional *Schema
+
+	// Known names the keys that are expected among the additional ones, such
+	// as the registered linters; other keys are reported as unknown
+	Known []string
+
+	// Items is the schema of the items of an array
+	Items *Schema
+
+	// Single accepts a single item in place of an array
+	Single bool
+
+	// Enum lists the allowed values of a string
+	Enum []string
+
+	// Pattern is a regular expression strings must match, with Format
+	// describing it in error messages
+	Pattern string
+	Format  string
+
+	// Minimum and Maximum bound an integer when not nil
+	Minimum *int
+	Maximum *int
+}
+
+// colorPattern matches hex colors such as "#7AA2F7" or "#fff", and ANSI
+// color numbers such as "205"
+const colorPattern = `^(#[0-9A-Fa-f]{6}|#[0-9A-Fa-f]{3}|[0-9]{1,3})$`
+
+// constraintPattern matches version constraints, clauses such as ">=1.10",
+// "^2", "~1.10.2" or "2.x" separated by commas or spaces
+const constraintPattern = `^\s*(\^|~|>=|<=|>|<|=)?\s*v?\d+(\.(\d+|[xX*])){0,2}(\s*,?\s*(\^|~|>=|<=|>|<|=)?\s*v?\d+(\.(\d+|[xX*])){0,2})*\s*$`
+
+// intPtr returns a pointer to n
+func intPtr(n int) *int {
+	return &n
+}
+
+// ConfigSchema returns the schema of lazylint.yaml
+func ConfigSchema() *Schema {
+	defaults := DefaultConfig()
+
+	var linterNames []string
+	for name := range defaults.Linters {
+		linterNames = append(linterNames, name)
+	}
+	sort.Strings(linterNames)
+
+	linter := &Schema{
+		Type:        "object",
+		Description: "Options of a linter",
+		Properties: map[string]*Schema{
+			"path":    {Type: "string", Description: "Command or path of the linter executable"},
+			"args":    {Type: "array", Items: &Schema{Type: "string"}, Description: "Arguments passed before the targets"},
+			"enabled": {Type: "boolean", Description: "Whether the linter runs"},
+			"version": {Type: "string", Description: "Versions of the linter LazyLint may run, e.g. \">=1.10, <3\" or \"^2\"",
+				Pattern: constraintPattern, Format: `a version constraint such as ">=1.10, <3"`},
+		},
+	}
+
+	color := func(description string) *Schema {
+		return &Schema{Type: "string", Description: description, Pattern: colorPattern, Format: `a hex color such as "#7AA2F7" or an ANSI color number`}
+	}
+	theme := &Schema{
+		Type:        "object",
+		Description: "A color theme",
+		Properties: map[string]*Schema{
+			"name": {Type: "string", Description: "Name of the theme"},
+			"colors": {
+				Type: "object",
+				Properties: map[string]*Schema{
+					"subtle":     color("Subtle elements"),
+					"highlight":  color("Highlighted elements and active borders"),
+					"special":    color("Special elements and successes"),
+					"error":      color("Errors"),
+					"warning":    color("Warnings"),
+					"border":     color("Borders"),
+					"text":       color("Main text"),
+					"dim_text":   color("Secondary text"),
+					"background": color("Background"),
+				},
+			},
+		},
+	}
+
+	linters := &Schema{
+		Type:        "object",
+		Description: "Options per linter",
+		Additional:  linter,
+		Known:       linterNames,
+	}
+
+	profile := &Schema{
+		Type:        "object",
+		Description: "A named set of linter options",
+		Properties: map[string]*Schema{
+			"description": {Type: "string", Description: "Description shown in the profile picker"},
+			"enabled":     {Type: "array", Items: &Schema{Type: "string"}, Description: "Linters the profile runs, the others are disabled"},
+			"linters":     linters,
+		},
+	}
+
+	override := &Schema{
+		Type:        "object",
+		Description: "Linter options for the files below some paths",
+		Properties: map[string]*Schema{
+			"paths":   {Type: "array", Items: &Schema{Type: "string"}, Description: "Globs relative to the repository root, such as services/legacy/**"},
+			"dir":     {Type: "string", Description: "Working directory of the linters, relative to the repository root"},
+			"linters": linters,
+		},
+	}
+
+	return &Schema{
+		Type:        "object",
+		Description: "LazyLint configuration",
+		Properties: map[string]*Schema{
+			"linters": linters,
+			"profile": {Type: "string", Description: "Name of the active profile"},
+			"profiles": {
+				Type:        "object",
+				Description: "Profiles by name, overriding the linters section while active",
+				Additional:  profile,
+			},
+			"overrides": {
+				Type:        "array",
+				Description: "Linter options per directory, the first matching entry applies",
+				Items:       override,
+			},
+			"ui": {
+				Type:        "object",
+				Description: "User interface settings",
+				Properties: map[string]*Schema{
+					"theme":  {Type: "string", Description: "Name of the active theme"},
+					"themes": {Type: "object", Description: "Color themes by name", Additional: theme},
+					"layout": {Type: "string", Description: "Layout of the UI", Enum: []string{LayoutTabs, LayoutPanes}},
+					"split": {Type: "integer", Description: "Width of the left column of the panes layout, in percent",
+						Minimum: intPtr(20), Maximum: intPtr(70)},
+				},
+			},
+			"report": {
+				Type:        "object",
+				Description: "Report settings",
+				Properties: map[string]*Schema{
+					"link_template": {Type: "string", Description: "Link to findings in markdown reports, with {commit}, {path} and {line} placeholders"},
+				},
+			},
+			"keys": {
+				Type:        "object",
+				Description: "Keys per action, replacing the default keys",
+				Additional:  &Schema{Type: "array", Items: &Schema{Type: "string"}, Single: true},
+			},
+		},
+	}
+}
+
+// Lookup returns the schema of the setting with the given dotted key, or nil
+// when the key is unknown
+func (s *Schema) Lookup(key string) *Schema {
+	schema := s
+	for _, part := range strings.Split(key, ".") {
+		if schema == nil || schema.Type != "object" {
+			return nil
+		}
+		if property, ok := schema.Properties[part]; ok {
+			schema = property
+		} else {
+			schema = schema.Additional
+		}
+	}
+	return schema
+}
+
+// JSONSchema renders the schema as a JSON Schema document for editors
+func (s *Schema) JSONSchema() ([]byte, error) {
+	document := s.jsonSchema()
+	document["$schema"] = "http://json-schema.org/draft-07/schema#"
+	document["title"] = "LazyLint configuration"
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// jsonSchema converts the schema to its JSON Schema representation
+func (s *Schema) jsonSchema() map[string]interface{} {
+	result := map[string]interface{}{}
+	if s.Description != "" {
+		result["description"] = s.Description
+	}
+
+	switch {
+	case s.Single:
+		result["type"] = []string{s.Type, s.Items.Type}
+	default:
+		result["type"] = s.Type
+	}
+
+	if s.Type == "object" {
+		properties := map[string]interface{}{}
+		for name, property := range s.Properties {
+			properties[name] = property.jsonSchema()
+		}
+		for _, name := range s.Known {
+			if _, ok := properties[name]; !ok && s.Additional != nil {
+				properties[name] = s.Additional.jsonSchema()
+			}
+		}
+		if len(properties) > 0 {
+			result["properties"] = properties
+		}
+		if s.Additional != nil {
+			result["additionalProperties"] = s.Additional.jsonSchema()
+		} else {
+			result["additionalProperties"] = false
+		}
+	}
+
+	if s.Items != nil {
+		result["items"] = s.Items.jsonSchema()
+	}
+	if len(s.Enum) > 0 {
+		result["enum"] = s.Enum
+	}
+	if s.Pattern != "" {
+		result["pattern"] = s.Pattern
+	}
+	if s.Minimum != nil {
+		result["minimum"] = *s.Minimum
+	}
+	if s.Maximum != nil {
+		result["maximum"] = *s.Maximum
+	}
+	return result
+}
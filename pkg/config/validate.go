@@ -0,0 +1,303 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is an issue found in the configuration
+type Problem struct {
+	// Source is the file, environment variable or flag of the problem
+	Source string
+
+	// Line and Column locate the problem in a file, 0 when unknown
+	Line   int
+	Column int
+
+	// Key is the dotted key of the setting, e.g. "linters.phpstan.args"
+	Key string
+
+	Message string
+
+	// Warning marks problems that don't stop LazyLint, such as unknown keys
+	Warning bool
+}
+
+// String formats the problem as "lazylint.yaml:3:5: key: message"
+func (p Problem) String() string {
+	location := p.Source
+	if p.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d", p.Source, p.Line, p.Column)
+	}
+
+	message := p.Message
+	if p.Key != "" {
+		message = p.Key + ": " + message
+	}
+	if p.Warning {
+		message = "warning: " + message
+	}
+	return location + ": " + message
+}
+
+// ValidationError is returned by Load when the configuration doesn't match
+// the schema
+type ValidationError struct {
+	// Problems holds the errors along with the warnings
+	Problems []Problem
+}
+
+// Error lists the problems, one per line
+func (e *ValidationError) Error() string {
+	lines := []string{"invalid configuration:"}
+	for _, problem := range e.Problems {
+		lines = append(lines, "  "+problem.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ValidateFile checks the config file at path against the schema
+func ValidateFile(path string) ([]Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ValidateData(path, data), nil
+}
+
+// ValidateData checks the YAML config in data, read from source, against the schema
+func ValidateData(source string, data []byte) []Problem {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return []Problem{{Source: source, Message: err.Error()}}
+	}
+
+	// An empty file has no content
+	if len(document.Content) == 0 {
+		return nil
+	}
+
+	v := &validator{source: source}
+	v.validate("", document.Content[0], ConfigSchema())
+	return v.problems
+}
+
+// validateOverride checks a value set by an environment variable or a flag
+func validateOverride(source, key string, value interface{}) []Problem {
+	schema := ConfigSchema().Lookup(key)
+	if schema == nil {
+		return []Problem{{Source: source, Key: key, Message: "unknown key", Warning: true}}
+	}
+
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return []Problem{{Source: source, Key: key, Message: err.Error()}}
+	}
+
+	v := &validator{source: source, noLines: true}
+	v.validate(key, &node, schema)
+	return v.problems
+}
+
+// HasErrors reports whether any of the problems isn't a warning
+func HasErrors(problems []Problem) bool {
+	for _, problem := range problems {
+		if !problem.Warning {
+			return true
+		}
+	}
+	return false
+}
+
+// validator collects the problems of a document
+type validator struct {
+	source   string
+	noLines  bool
+	problems []Problem
+}
+
+// report adds a problem at the node
+func (v *validator) report(node *yaml.Node, key string, warning bool, format string, args ...interface{}) {
+	problem := Problem{Source: v.source, Key: key, Message: fmt.Sprintf(format, args...), Warning: warning}
+	if !v.noLines {
+		problem.Line, problem.Column = node.Line, node.Column
+	}
+	v.problems = append(v.problems, problem)
+}
+
+// validate checks the node against the schema
+func (v *validator) validate(key string, node *yaml.Node, schema *Schema) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	// Empty values keep the defaults
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	switch schema.Type {
+	case "object":
+		if node.Kind != yaml.MappingNode {
+			v.report(node, key, false, "expected a map, got %s", describe(node))
+			return
+		}
+		v.validateObject(key, node, schema)
+
+	case "array":
+		if node.Kind == yaml.ScalarNode && schema.Single {
+			v.validate(key, node, schema.Items)
+			return
+		}
+		if node.Kind != yaml.SequenceNode {
+			v.report(node, key, false, "expected a list of %ss, got %s", schema.Items.Type, describe(node))
+			return
+		}
+		for i, item := range node.Content {
+			v.validate(fmt.Sprintf("%s[%d]", key, i), item, schema.Items)
+		}
+
+	case "string":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+			v.report(node, key, false, "expected a string, got %s", describe(node))
+			return
+		}
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, node.Value) {
+			v.report(node, key, false, "expected one of %s, got %q", strings.Join(schema.Enum, ", "), node.Value)
+		}
+		if schema.Pattern != "" && !regexp.MustCompile(schema.Pattern).MatchString(node.Value) {
+			v.report(node, key, false, "expected %s, got %q", schema.Format, node.Value)
+		}
+
+	case "boolean":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			v.report(node, key, false, "expected true or false, got %s", describe(node))
+		}
+
+	case "integer":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			v.report(node, key, false, "expected a number, got %s", describe(node))
+			return
+		}
+		n, err := strconv.Atoi(node.Value)
+		if err != nil {
+			v.report(node, key, false, "expected a number, got %q", node.Value)
+			return
+		}
+		if (schema.Minimum != nil && n < *schema.Minimum) || (schema.Maximum != nil && n > *schema.Maximum) {
+			v.report(node, key, false, "expected %s, got %d", describeRange(schema), n)
+		}
+	}
+}
+
+// describeRange describes the bounds of an integer schema, e.g. "a number from 20 to 70"
+func describeRange(schema *Schema) string {
+	switch {
+	case schema.Minimum != nil && schema.Maximum != nil:
+		return fmt.Sprintf("a number from %d to %d", *schema.Minimum, *schema.Maximum)
+	case schema.Minimum != nil:
+		return fmt.Sprintf("a number of at least %d", *schema.Minimum)
+	case schema.Maximum != nil:
+		return fmt.Sprintf("a number of at most %d", *schema.Maximum)
+	}
+	return "a number"
+}
+
+// validateObject checks the keys and values of a map
+func (v *validator) validateObject(key string, node *yaml.Node, schema *Schema) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+
+		// Viper ignores the case of keys
+		name := strings.ToLower(keyNode.Value)
+		child := name
+		if key != "" {
+			child = key + "." + name
+		}
+
+		if property, ok := schema.Properties[name]; ok {
+			v.validate(child, valueNode, property)
+			continue
+		}
+		if schema.Additional == nil {
+			v.report(keyNode, child, true, "unknown key%s", suggest(name, schema.Properties))
+			continue
+		}
+		if len(schema.Known) > 0 && !slices.Contains(schema.Known, name) {
+			v.report(keyNode, child, true, "unknown name %q%s", name, suggestName(name, schema.Known))
+		}
+		v.validate(child, valueNode, schema.Additional)
+	}
+}
+
+// describe names the kind of value of a node for error messages
+func describe(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a map"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+
+	switch node.Tag {
+	case "!!bool":
+		return "a boolean"
+	case "!!int", "!!float":
+		return "a number"
+	case "!!str":
+		return fmt.Sprintf("the string %q", node.Value)
+	}
+	return fmt.Sprintf("%q", node.Value)
+}
+
+// suggest proposes the known key closest to name, if any is close
+func suggest(name string, properties map[string]*Schema) string {
+	var names []string
+	for property := range properties {
+		names = append(names, property)
+	}
+	slices.Sort(names)
+	return suggestName(name, names)
+}
+
+// suggestName proposes the name closest to name, if any is close
+func suggestName(name string, names []string) string {
+	best, bestDistance := "", 3
+	for _, candidate := range names {
+		if d := distance(name, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %q?", best)
+}
+
+// distance returns the Levenshtein distance between a and b
+func distance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
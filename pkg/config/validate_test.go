@@ -0,0 +1,151 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateData(t *testing.T) {
+	data := `linters:
+  phpstan:
+    enabeld: true
+    args: "--level=8"
+  phpstna:
+    enabled: yes
+ui:
+  layout: pane
+  split: 90
+  themes:
+    mine:
+      colors:
+        text: blue
+        error: "#ff0000"
+keys:
+  quit: q
+  help: [h, "?"]
+`
+	problems := ValidateData("lazylint.yaml", []byte(data))
+
+	want := []string{
+		`lazylint.yaml:3:5: warning: linters.phpstan.enabeld: unknown key, did you mean "enabled"?`,
+		`lazylint.yaml:4:11: linters.phpstan.args: expected a list of strings, got the string "--level=8"`,
+		`lazylint.yaml:5:3: warning: linters.phpstna: unknown name "phpstna", did you mean "phpstan"?`,
+		`lazylint.yaml:6:14: linters.phpstna.enabled: expected true or false, got the string "yes"`,
+		`lazylint.yaml:8:11: ui.layout: expected one of tabs, panes, got "pane"`,
+		`lazylint.yaml:9:10: ui.split: expected a number from 20 to 70, got 90`,
+		`lazylint.yaml:13:15: ui.themes.mine.colors.text: expected a hex color such as "#7AA2F7" or an ANSI color number, got "blue"`,
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("Got %d problems, want %d: %v", len(problems), len(want), problems)
+	}
+	for i, problem := range problems {
+		if problem.String() != want[i] {
+			t.Errorf("Problem %d = %s, want %s", i, problem, want[i])
+		}
+	}
+	if !HasErrors(problems) {
+		t.Error("Expected the problems to hold errors")
+	}
+}
+
+func TestValidateVersion(t *testing.T) {
+	data := `linters:
+  phpstan:
+    version: ">= 1.10, <3"
+  golangci-lint:
+    version: latest
+`
+	problems := ValidateData("lazylint.yaml", []byte(data))
+
+	want := `lazylint.yaml:5:14: linters.golangci-lint.version: expected a version constraint such as ">=1.10, <3", got "latest"`
+	if len(problems) != 1 || problems[0].String() != want {
+		t.Errorf("Got problems %v, want %s", problems, want)
+	}
+}
+
+func TestValidateDefaultConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazylint.yaml")
+	if err := SaveConfig(DefaultConfig(), path); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+
+	problems, err := ValidateFile(path)
+	if err != nil {
+		t.Fatalf("ValidateFile failed: %v", err)
+	}
+	if len(problems) > 0 {
+		t.Errorf("Expected the default config to be valid, got %v", problems)
+	}
+}
+
+func TestLoadValidation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	// Unknown keys are warnings
+	writeConfig(t, filepath.Join(dir, "lazylint.yaml"), "ui:\n  them: light\n")
+	loaded, err := Load(LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.Warnings) != 1 || loaded.Warnings[0].Key != "ui.them" {
+		t.Errorf("Warnings = %v, want one for ui.them", loaded.Warnings)
+	}
+
+	// Wrong types are errors, whatever layer they come from
+	t.Setenv("LAZYLINT_UI_SPLIT", "wide")
+	_, err = Load(LoadOptions{})
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Load error = %v, want a ValidationError", err)
+	}
+	if !strings.Contains(err.Error(), "LAZYLINT_UI_SPLIT: ui.split: expected a number") {
+		t.Errorf("Error = %q, want it to name the environment variable", err)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := ConfigSchema().JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema failed: %v", err)
+	}
+
+	var document struct {
+		Properties map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	for _, name := range []string{"phpstan", "golangci-lint"} {
+		if _, ok := document.Properties["linters"].Properties[name]; !ok {
+			t.Errorf("Expected the schema to list the %s linter", name)
+		}
+	}
+	if _, ok := document.Properties["ui"].Properties["split"]; !ok {
+		t.Error("Expected the schema to describe ui.split")
+	}
+}
+
+func TestDescribeRange(t *testing.T) {
+	testCases := []struct {
+		schema *Schema
+		want   string
+	}{
+		{&Schema{Minimum: intPtr(20), Maximum: intPtr(70)}, "a number from 20 to 70"},
+		{&Schema{Minimum: intPtr(1)}, "a number of at least 1"},
+		{&Schema{Maximum: intPtr(9)}, "a number of at most 9"},
+		{&Schema{}, "a number"},
+	}
+
+	for _, tc := range testCases {
+		if got := describeRange(tc.schema); got != tc.want {
+			t.Errorf("describeRange() = %q, want %q", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch calls onChange with the path of any of the given config files when
+// it's created, written, replaced or removed, until the program exits. The
+// directories of the files are watched, so that editors replacing a file on
+// save and files created later, such as a new lazylint.local.yaml, are
+// noticed too. Directories that don't exist are skipped.
+func Watch(paths []string, onChange func(path string)) error {
+	watched := make(map[string]string)
+	for _, path := range paths {
+		if abs, err := filepath.Abs(path); err == nil {
+			watched[abs] = path
+		}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	dirs := make(map[string]bool)
+	for abs := range watched {
+		dir := filepath.Dir(abs)
+		if dirs[dir] {
+			continue
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		if err := watcher.Add(dir); err == nil {
+			dirs[dir] = true
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+					continue
+				}
+				if path, ok := watched[filepath.Clean(event.Name)]; ok {
+					onChange(path)
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
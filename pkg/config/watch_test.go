@@ -0,0 +1,53 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "lazylint.yaml")
+	writeConfig(t, path, "ui:\n  theme: light\n")
+
+	changes := make(chan string, 10)
+	if err := Watch([]string{path, filepath.Join(dir, "missing", "lazylint.yaml")}, func(changed string) {
+		changes <- changed
+	}); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	writeConfig(t, path, "ui:\n  theme: catppuccin\n")
+	select {
+	case changed := <-changes:
+		if changed != path {
+			t.Errorf("Changed path = %q, want %q", changed, path)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the change")
+	}
+}
+
+func TestWatchCreated(t *testing.T) {
+	dir := t.TempDir()
+	local := filepath.Join(dir, LocalConfigName)
+
+	// The local override doesn't exist yet
+	changes := make(chan string, 10)
+	if err := Watch([]string{filepath.Join(dir, "lazylint.yaml"), local}, func(changed string) {
+		changes <- changed
+	}); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	writeConfig(t, local, "ui:\n  split: 40\n")
+	select {
+	case changed := <-changes:
+		if changed != local {
+			t.Errorf("Changed path = %q, want %q", changed, local)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the new file")
+	}
+}
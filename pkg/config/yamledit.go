@@ -0,0 +1,103 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// setYAMLValues sets the values of dotted keys such as "ui.layout" in the
+// YAML document, adding missing keys at the end of their mapping. Only the
+// edited values change: comments, the order and the spelling of the other
+// keys are kept.
+func setYAMLValues(data []byte, values map[string]interface{}) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config is not a mapping of settings")
+	}
+
+	// Apply the values in a stable order, so new keys are added deterministically
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := setYAMLValue(root, strings.Split(key, "."), values[key]); err != nil {
+			return nil, fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// setYAMLValue sets the value at path below the mapping node. Keys match
+// case-insensitively, like viper reads them.
+func setYAMLValue(mapping *yaml.Node, path []string, value interface{}) error {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if !strings.EqualFold(mapping.Content[i].Value, path[0]) {
+			continue
+		}
+
+		old := mapping.Content[i+1]
+		if len(path) == 1 {
+			node, err := encodeYAMLValue(value)
+			if err != nil {
+				return err
+			}
+			node.LineComment = old.LineComment
+			mapping.Content[i+1] = node
+			return nil
+		}
+
+		// Replace scalars in the way of nested keys by a mapping
+		if old.Kind != yaml.MappingNode {
+			old = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", LineComment: old.LineComment}
+			mapping.Content[i+1] = old
+		}
+		return setYAMLValue(old, path[1:], value)
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path[0]}
+	if len(path) == 1 {
+		node, err := encodeYAMLValue(value)
+		if err != nil {
+			return err
+		}
+		mapping.Content = append(mapping.Content, key, node)
+		return nil
+	}
+
+	child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	mapping.Content = append(mapping.Content, key, child)
+	return setYAMLValue(child, path[1:], value)
+}
+
+// encodeYAMLValue converts a value to a YAML node
+func encodeYAMLValue(value interface{}) (*yaml.Node, error) {
+	node := &yaml.Node{}
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
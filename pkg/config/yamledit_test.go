@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestSetYAMLValues(t *testing.T) {
+	data := []byte(`# Personal settings
+ui:
+  theme: light # easier on the eyes
+  Layout: tabs
+linters:
+  phpstan:
+    args: [analyse, --level=8]
+`)
+
+	updated, err := setYAMLValues(data, map[string]interface{}{
+		"ui.layout":            "panes",
+		"ui.split":             40,
+		"report.link_template": "{path}",
+	})
+	if err != nil {
+		t.Fatalf("setYAMLValues() error = %v", err)
+	}
+
+	want := `# Personal settings
+ui:
+  theme: light # easier on the eyes
+  Layout: panes
+  split: 40
+linters:
+  phpstan:
+    args: [analyse, --level=8]
+report:
+  link_template: '{path}'
+`
+	if string(updated) != want {
+		t.Errorf("setYAMLValues() =\n%s\nwant\n%s", updated, want)
+	}
+}
+
+func TestSetYAMLValuesEmptyFile(t *testing.T) {
+	updated, err := setYAMLValues(nil, map[string]interface{}{"ui.layout": "panes"})
+	if err != nil {
+		t.Fatalf("setYAMLValues() error = %v", err)
+	}
+	if want := "ui:\n  layout: panes\n"; string(updated) != want {
+		t.Errorf("setYAMLValues() = %q, want %q", updated, want)
+	}
+
+	if _, err := setYAMLValues([]byte("- not\n- a mapping\n"), map[string]interface{}{"ui.layout": "panes"}); err == nil {
+		t.Error("expected an error for a sequence document")
+	}
+}
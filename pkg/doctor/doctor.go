@@ -0,0 +1,184 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/linters"
+	"github.com/crixuamg/pkg/workspace"
+)
+
+// Check is the diagnosis of a linter
+type Check struct {
+	Name    string
+	Enabled bool
+
+	// Binary is the executable of the linter, found from Dir
+	Binary linters.Binary
+	Dir    string
+
+	// Version is the first line printed by the executable for --version.
+	// VersionErr also reports a version outside the Constraint of the config.
+	Version    string
+	VersionErr error
+	Constraint string
+
+	// ConfigFiles are the configuration files of the linter in the
+	// repository root and the sub-projects, relative to the root, found by
+	// the names of ConfigNames
+	ConfigFiles []string
+	ConfigNames []string
+}
+
+// Usable reports whether the linter can run
+func (c Check) Usable() bool {
+	return c.Binary.Err == nil && c.VersionErr == nil
+}
+
+// Problem explains why the linter can't run, empty when it can
+func (c Check) Problem() string {
+	switch {
+	case c.Binary.Err != nil:
+		return c.Binary.Err.Error()
+	case c.VersionErr != nil:
+		return c.VersionErr.Error()
+	}
+	return ""
+}
+
+// Failed reports whether one of the enabled linters can't run
+func Failed(checks []Check) bool {
+	for _, check := range checks {
+		if check.Enabled && !check.Usable() {
+			return true
+		}
+	}
+	return false
+}
+
+// Run diagnoses the linters of the registry in the repository at root,
+// sorted by name. Executables are looked up from the root first and from the
+// sub-projects of ws otherwise.
+func Run(ctx context.Context, cfg *config.Config, registry *linters.Registry, ws *workspace.Workspace, root string) []Check {
+	dirs := append([]string{root}, ws.Dirs()...)
+
+	all := registry.GetAll()
+	checks := make([]Check, len(all))
+	var wg sync.WaitGroup
+	for i, linter := range all {
+		wg.Add(1)
+		go func(i int, linter linters.Linter) {
+			defer wg.Done()
+			checks[i] = diagnose(ctx, cfg, linter, root, dirs)
+		}(i, linter)
+	}
+	wg.Wait()
+
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].Name < checks[j].Name
+	})
+	return checks
+}
+
+// diagnose checks a single linter
+func diagnose(ctx context.Context, cfg *config.Config, linter linters.Linter, root string, dirs []string) Check {
+	check := Check{
+		Name:        linter.Name(),
+		Enabled:     cfg.LinterEnabled(linter.Name()),
+		ConfigNames: linters.ConfigFileNames(linter.Name()),
+	}
+
+	// Use the first directory the executable is found from, reporting the
+	// problem of the root otherwise
+	for i, dir := range dirs {
+		binary := linters.LinterBinary(linter, dir)
+		if i == 0 || binary.Err == nil {
+			check.Binary, check.Dir = binary, dir
+		}
+		if binary.Err == nil {
+			break
+		}
+	}
+	if check.Binary.Err == nil {
+		check.Version, check.VersionErr = linters.VersionOutput(ctx, check.Binary.Path, check.Dir)
+	}
+	check.Constraint, _ = cfg.LinterOptions(linter.Name())["version"].(string)
+	if check.Constraint != "" && check.VersionErr == nil {
+		check.VersionErr = checkConstraint(check.Constraint, check.Version)
+	}
+
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		file := linters.FindConfigFile(linter.Name(), dir)
+		if file == "" {
+			continue
+		}
+		if rel, err := filepath.Rel(root, file); err == nil {
+			file = rel
+		}
+		if !seen[file] {
+			seen[file] = true
+			check.ConfigFiles = append(check.ConfigFiles, filepath.ToSlash(file))
+		}
+	}
+
+	return check
+}
+
+// checkConstraint returns an error when the version printed by a linter
+// doesn't satisfy the constraint
+func checkConstraint(text, output string) error {
+	constraint, err := linters.ParseConstraint(text)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		return nil
+	}
+	version, ok := linters.ParseVersion(output)
+	if !ok {
+		return fmt.Errorf("can't check the version constraint %q: no version number in %q", text, output)
+	}
+	return linters.CheckConstraint(constraint, version)
+}
+
+// Describe returns the lines describing a check, without the name
+func (c Check) Describe() []string {
+	var lines []string
+
+	state := "disabled"
+	if c.Enabled {
+		state = "enabled"
+	}
+	lines = append(lines, "status   "+state)
+
+	if c.Binary.Err != nil {
+		lines = append(lines, "binary   not usable: "+c.Binary.Err.Error())
+	} else {
+		lines = append(lines, fmt.Sprintf("binary   %s (%s)", c.Binary.Path, c.Binary.Source))
+	}
+
+	switch {
+	case c.VersionErr != nil:
+		lines = append(lines, "version  "+c.VersionErr.Error())
+	case c.Version != "" && c.Constraint != "":
+		lines = append(lines, fmt.Sprintf("version  %s, satisfies %s", c.Version, c.Constraint))
+	case c.Version != "":
+		lines = append(lines, "version  "+c.Version)
+	}
+
+	switch {
+	case len(c.ConfigFiles) > 0:
+		lines = append(lines, "config   "+strings.Join(c.ConfigFiles, ", "))
+	case len(c.ConfigNames) > 0:
+		lines = append(lines, "config   none found, looked for "+strings.Join(c.ConfigNames, ", "))
+	default:
+		lines = append(lines, "config   not used")
+	}
+	return lines
+}
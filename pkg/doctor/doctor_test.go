@@ -0,0 +1,97 @@
+package doctor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/linters"
+)
+
+// newRepository creates a repository with PHPStan 1.10.50 installed with
+// composer and an empty PATH. It returns the root and the vendor/bin directory.
+func newRepository(t *testing.T) (string, string) {
+	t.Helper()
+	root := t.TempDir()
+	bin := filepath.Join(root, "vendor", "bin")
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\necho 'PHPStan - PHP Static Analysis Tool 1.10.50'\n"
+	if err := os.WriteFile(filepath.Join(bin, "phpstan"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to write phpstan: %v", err)
+	}
+	t.Setenv("PATH", t.TempDir())
+	return root, bin
+}
+
+func TestRun(t *testing.T) {
+	// PHPStan is installed with composer, PHPCS is missing
+	root, bin := newRepository(t)
+	if err := os.WriteFile(filepath.Join(root, "phpstan.neon"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := linters.NewRegistry()
+	registry.Register(linters.NewPHPStan())
+	registry.Register(linters.NewPHPCS())
+
+	cfg := config.DefaultConfig()
+	cfg.Linters["phpcs"]["enabled"] = false
+	checks := Run(context.Background(), cfg, registry, nil, root)
+	if len(checks) != 2 {
+		t.Fatalf("Got %d checks, want 2", len(checks))
+	}
+
+	phpcs, phpstan := checks[0], checks[1]
+	if !phpstan.Usable() || phpstan.Binary.Source != "vendor/bin" || phpstan.Binary.Path != filepath.Join(bin, "phpstan") {
+		t.Errorf("phpstan = %+v, want it found in vendor/bin", phpstan)
+	}
+	if phpstan.Version != "PHPStan - PHP Static Analysis Tool 1.10.50" {
+		t.Errorf("Version = %q", phpstan.Version)
+	}
+	if !reflect.DeepEqual(phpstan.ConfigFiles, []string{"phpstan.neon"}) {
+		t.Errorf("ConfigFiles = %v, want phpstan.neon", phpstan.ConfigFiles)
+	}
+
+	if phpcs.Usable() || !strings.Contains(phpcs.Problem(), "phpcs was not found in vendor/bin or the PATH") {
+		t.Errorf("phpcs problem = %q, want it missing", phpcs.Problem())
+	}
+
+	// Only enabled linters that can't run fail the diagnosis
+	if Failed(checks) {
+		t.Error("Expected the disabled phpcs not to fail the diagnosis")
+	}
+	checks[0].Enabled = true
+	if !Failed(checks) {
+		t.Error("Expected the enabled phpcs to fail the diagnosis")
+	}
+}
+
+func TestRunConstraint(t *testing.T) {
+	root, _ := newRepository(t)
+
+	registry := linters.NewRegistry()
+	registry.Register(linters.NewPHPStan())
+
+	cfg := config.DefaultConfig()
+	cfg.Linters["phpstan"]["version"] = "^1.10"
+	checks := Run(context.Background(), cfg, registry, nil, root)
+	if !checks[0].Usable() {
+		t.Errorf("phpstan problem = %q, want 1.10.50 to satisfy ^1.10", checks[0].Problem())
+	}
+
+	// Version 2 is required but 1.10.50 is installed
+	cfg.Linters["phpstan"]["version"] = ">=2"
+	checks = Run(context.Background(), cfg, registry, nil, root)
+	if checks[0].Usable() || !strings.Contains(checks[0].Problem(), `1.10.50 does not satisfy the version constraint ">=2"`) {
+		t.Errorf("phpstan problem = %q, want the unmet constraint", checks[0].Problem())
+	}
+}
@@ -0,0 +1,85 @@
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// run executes a git command in the given directory and returns its trimmed output
+func run(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// HeadCommit returns the abbreviated hash of the current HEAD commit
+func HeadCommit(dir string) (string, error) {
+	return run(dir, "rev-parse", "--short", "HEAD")
+}
+
+// splitNul splits NUL separated git output, dropping empty entries
+func splitNul(out string) []string {
+	var result []string
+	for _, part := range strings.Split(out, "\x00") {
+		if part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// StagedFiles returns the repository-relative paths of files added, copied,
+// modified or renamed in the index
+func StagedFiles(dir string) ([]string, error) {
+	out, err := run(dir, "diff", "--cached", "--name-only", "--diff-filter=ACMR", "-z")
+	if err != nil {
+		return nil, err
+	}
+	return splitNul(out), nil
+}
+
+// ChangedFiles returns the repository-relative paths of files changed
+// between the merge base of ref and HEAD
+func ChangedFiles(dir, ref string) ([]string, error) {
+	out, err := run(dir, "diff", "--name-only", "--diff-filter=ACMR", "-z", ref+"...HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return splitNul(out), nil
+}
+
+// Upstream returns the upstream branch of the current branch, e.g. "origin/main"
+func Upstream(dir string) (string, error) {
+	return run(dir, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+}
+
+// HooksDir returns the directory git runs hooks from, honoring core.hooksPath
+func HooksDir(dir string) (string, error) {
+	out, err := run(dir, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(out) {
+		out = filepath.Join(dir, out)
+	}
+	return out, nil
+}
+
+// CheckoutIndex writes the index version of the given files below prefix,
+// preserving their repository-relative paths
+func CheckoutIndex(dir, prefix string, files []string) error {
+	if len(files) == 0 {
+		return nil
+	}
+	if !strings.HasSuffix(prefix, string(filepath.Separator)) {
+		prefix += string(filepath.Separator)
+	}
+	args := append([]string{"checkout-index", "--prefix=" + prefix, "--"}, files...)
+	_, err := run(dir, args...)
+	return err
+}
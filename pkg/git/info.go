@@ -0,0 +1,92 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RepoInfo summarizes the branch and the working tree of a repository
+type RepoInfo struct {
+	// Branch is the current branch, empty when HEAD is detached
+	Branch string
+
+	// Head is the abbreviated hash of HEAD, empty before the first commit
+	Head string
+
+	// Subject is the subject of the HEAD commit
+	Subject string
+
+	// Upstream is the upstream branch, e.g. "origin/main", and the number of
+	// commits the branch is ahead and behind it
+	Upstream string
+	Ahead    int
+	Behind   int
+
+	// Numbers of files with staged, unstaged, untracked and conflicting changes
+	Staged     int
+	Unstaged   int
+	Untracked  int
+	Conflicted int
+}
+
+// Dirty reports whether the working tree or the index has changes
+func (i RepoInfo) Dirty() bool {
+	return i.Staged+i.Unstaged+i.Untracked+i.Conflicted > 0
+}
+
+// Info returns the branch and working tree summary of the repository at dir
+func Info(dir string) (*RepoInfo, error) {
+	out, err := run(dir, "status", "--porcelain=v2", "--branch", "-z", "--untracked-files=all")
+	if err != nil {
+		return nil, err
+	}
+
+	info := &RepoInfo{}
+	entries := splitNul(out)
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		switch {
+		case strings.HasPrefix(entry, "# branch.oid "):
+			if oid := strings.TrimPrefix(entry, "# branch.oid "); oid != "(initial)" && len(oid) >= 7 {
+				info.Head = oid[:7]
+			}
+		case strings.HasPrefix(entry, "# branch.head "):
+			if head := strings.TrimPrefix(entry, "# branch.head "); head != "(detached)" {
+				info.Branch = head
+			}
+		case strings.HasPrefix(entry, "# branch.upstream "):
+			info.Upstream = strings.TrimPrefix(entry, "# branch.upstream ")
+		case strings.HasPrefix(entry, "# branch.ab "):
+			fmt.Sscanf(strings.TrimPrefix(entry, "# branch.ab "), "+%d -%d", &info.Ahead, &info.Behind)
+		case strings.HasPrefix(entry, "1 "), strings.HasPrefix(entry, "2 "):
+			// Changed entries start with the index and work tree status, "." for unchanged
+			if len(entry) < 4 {
+				continue
+			}
+			if entry[2] != '.' {
+				info.Staged++
+			}
+			if entry[3] != '.' {
+				info.Unstaged++
+			}
+
+			// Renames and copies are followed by their original path
+			if entry[0] == '2' {
+				i++
+			}
+		case strings.HasPrefix(entry, "u "):
+			info.Conflicted++
+		case strings.HasPrefix(entry, "? "):
+			info.Untracked++
+		}
+	}
+
+	// A new repository has no commit to describe
+	if info.Head != "" {
+		if subject, err := run(dir, "log", "-1", "--format=%s"); err == nil {
+			info.Subject = subject
+		}
+	}
+
+	return info, nil
+}
@@ -0,0 +1,61 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestInfo(t *testing.T) {
+	origin := initRepo(t, map[string]string{
+		"a.php": "<?php\n",
+		"b.php": "<?php\n",
+	})
+
+	// Clone the repository and commit on top of it to get ahead of its upstream
+	repo := filepath.Join(t.TempDir(), "clone")
+	if _, err := run(origin, "clone", "-q", origin, repo); err != nil {
+		t.Fatalf("git clone failed: %v", err)
+	}
+	for _, args := range [][]string{
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"commit", "-q", "--allow-empty", "-m", "Add the second commit"},
+	} {
+		if _, err := run(repo, args...); err != nil {
+			t.Fatalf("git %v failed: %v", args, err)
+		}
+	}
+
+	writeTestFile(t, filepath.Join(repo, "a.php"), "<?php\n// changed\n")
+	writeTestFile(t, filepath.Join(repo, "b.php"), "<?php\n// staged\n")
+	writeTestFile(t, filepath.Join(repo, "new.php"), "<?php\n")
+	if _, err := run(repo, "add", "b.php"); err != nil {
+		t.Fatalf("git add failed: %v", err)
+	}
+
+	info, err := Info(repo)
+	if err != nil {
+		t.Fatalf("Info failed: %v", err)
+	}
+
+	branch, err := run(repo, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		t.Fatalf("git rev-parse failed: %v", err)
+	}
+	if info.Branch != branch {
+		t.Errorf("Branch = %q, want %q", info.Branch, branch)
+	}
+	if info.Upstream != "origin/"+branch || info.Ahead != 1 || info.Behind != 0 {
+		t.Errorf("Upstream = %q +%d -%d, want origin/%s +1 -0", info.Upstream, info.Ahead, info.Behind, branch)
+	}
+	if info.Subject != "Add the second commit" || len(info.Head) != 7 {
+		t.Errorf("Head = %q %q, want the second commit", info.Head, info.Subject)
+	}
+	if info.Staged != 1 || info.Unstaged != 1 || info.Untracked != 1 || info.Conflicted != 0 {
+		t.Errorf("Counts = %d staged, %d unstaged, %d untracked, %d conflicted, want 1, 1, 1, 0",
+			info.Staged, info.Unstaged, info.Untracked, info.Conflicted)
+	}
+	if !info.Dirty() {
+		t.Error("Expected a dirty working tree")
+	}
+}
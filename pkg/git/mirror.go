@@ -0,0 +1,176 @@
+package git
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConfigFiles are linter configuration files copied into a mirror so the
+// linters behave as they do in the repository
+var ConfigFiles = []string{
+	"lazylint.yaml", "lazylint.yml",
+	"phpstan.neon", "phpstan.neon.dist", "phpstan.dist.neon",
+	"phpcs.xml", "phpcs.xml.dist", ".phpcs.xml", ".phpcs.xml.dist",
+	".golangci.yml", ".golangci.yaml", ".golangci.toml", ".golangci.json",
+	".eslintrc", ".eslintrc.js", ".eslintrc.cjs", ".eslintrc.json", ".eslintrc.yml", ".eslintrc.yaml",
+	"eslint.config.js", "eslint.config.mjs", "eslint.config.cjs", ".eslintignore",
+	"composer.json", "go.mod", "go.sum", "package.json", "tsconfig.json",
+}
+
+// linkedDirs are dependency directories symlinked into a mirror instead of copied
+var linkedDirs = []string{"vendor", "node_modules"}
+
+// Mirror is a temporary tree holding the index (staged) version of files,
+// laid out with the same relative paths as the repository
+type Mirror struct {
+	// Root is the temporary directory holding the mirrored files
+	Root string
+
+	// RepoRoot is the repository the files were taken from
+	RepoRoot string
+
+	// Files are the repository-relative paths of the mirrored files
+	Files []string
+}
+
+// NewIndexMirror materializes the index version of the given
+// repository-relative files, plus the linter configuration files that apply
+// to them, into a new temporary directory. Close removes the directory.
+func NewIndexMirror(repoRoot string, files []string) (*Mirror, error) {
+	root, err := os.MkdirTemp("", "lazylint-index-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mirror directory: %w", err)
+	}
+
+	m := &Mirror{Root: root, RepoRoot: repoRoot, Files: files}
+	if err := CheckoutIndex(repoRoot, root, files); err != nil {
+		m.Close()
+		return nil, fmt.Errorf("failed to materialize index: %w", err)
+	}
+
+	if err := m.copyConfigFiles(); err != nil {
+		m.Close()
+		return nil, err
+	}
+
+	// Dependencies are needed for autoloading and plugins but never change in the index
+	if err := m.linkDependencies(); err != nil {
+		m.Close()
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// dirs returns the repository-relative directories of the mirrored files and
+// their parents, including the root
+func (m *Mirror) dirs() map[string]bool {
+	dirs := map[string]bool{".": true}
+	for _, file := range m.Files {
+		for dir := filepath.Dir(file); dir != "." && dir != "/" && !dirs[dir]; dir = filepath.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+	return dirs
+}
+
+// linkDependencies symlinks the dependency directories of the repository
+// root and of the sub-projects holding mirrored files
+func (m *Mirror) linkDependencies() error {
+	for dir := range m.dirs() {
+		for _, name := range linkedDirs {
+			source := filepath.Join(m.RepoRoot, dir, name)
+			info, err := os.Stat(source)
+			if err != nil || !info.IsDir() {
+				continue
+			}
+
+			target := filepath.Join(m.Root, dir, name)
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+			}
+			if err := os.Symlink(source, target); err != nil {
+				return fmt.Errorf("failed to link %s: %w", filepath.Join(dir, name), err)
+			}
+		}
+	}
+	return nil
+}
+
+// copyConfigFiles mirrors the configuration files found in the directories
+// of the mirrored files and their parents, preferring the index version
+func (m *Mirror) copyConfigFiles() error {
+	for dir := range m.dirs() {
+		for _, name := range ConfigFiles {
+			rel := filepath.Join(dir, name)
+			target := filepath.Join(m.Root, rel)
+			if _, err := os.Stat(target); err == nil {
+				continue
+			}
+
+			// Tracked config files come from the index, untracked ones from the working tree
+			if content, err := run(m.RepoRoot, "show", ":"+filepath.ToSlash(rel)); err == nil {
+				if err := writeFile(target, strings.NewReader(content+"\n")); err != nil {
+					return err
+				}
+				continue
+			}
+
+			source, err := os.Open(filepath.Join(m.RepoRoot, rel))
+			if err != nil {
+				continue
+			}
+			err = writeFile(target, source)
+			source.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeFile writes the content of r to path, creating parent directories
+func writeFile(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, r); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Paths returns the absolute mirror paths of the mirrored files
+func (m *Mirror) Paths() []string {
+	paths := make([]string, len(m.Files))
+	for i, file := range m.Files {
+		paths[i] = filepath.Join(m.Root, file)
+	}
+	return paths
+}
+
+// MapOutput rewrites mirror paths in linter output to repository paths
+func (m *Mirror) MapOutput(output string) string {
+	// macOS reports temporary directories through the /private symlink
+	if resolved, err := filepath.EvalSymlinks(m.Root); err == nil && resolved != m.Root {
+		output = strings.ReplaceAll(output, resolved, m.RepoRoot)
+	}
+	return strings.ReplaceAll(output, m.Root, m.RepoRoot)
+}
+
+// Close removes the mirror directory
+func (m *Mirror) Close() error {
+	return os.RemoveAll(m.Root)
+}
@@ -0,0 +1,104 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// initRepo creates a git repository with the given committed files
+func initRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+	} {
+		if _, err := run(dir, args...); err != nil {
+			t.Fatalf("git %v failed: %v", args, err)
+		}
+	}
+
+	for path, content := range files {
+		writeTestFile(t, filepath.Join(dir, path), content)
+	}
+	if _, err := run(dir, "add", "-A"); err != nil {
+		t.Fatalf("git add failed: %v", err)
+	}
+	if _, err := run(dir, "commit", "-q", "-m", "initial"); err != nil {
+		t.Fatalf("git commit failed: %v", err)
+	}
+	return dir
+}
+
+// writeTestFile writes content to path, creating parent directories
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestNewIndexMirror(t *testing.T) {
+	repo := initRepo(t, map[string]string{
+		"phpstan.neon":    "parameters:\n  level: 5\n",
+		"src/Foo.php":     "<?php\n",
+		"src/.eslintrc":   "{}\n",
+		"other/Other.php": "<?php\n",
+	})
+
+	// Stage one version and keep a different one in the working tree
+	writeTestFile(t, filepath.Join(repo, "src", "Foo.php"), "<?php\n// staged\n")
+	if _, err := run(repo, "add", "src/Foo.php"); err != nil {
+		t.Fatalf("git add failed: %v", err)
+	}
+	writeTestFile(t, filepath.Join(repo, "src", "Foo.php"), "<?php\n// unstaged\n")
+
+	staged, err := StagedFiles(repo)
+	if err != nil {
+		t.Fatalf("StagedFiles failed: %v", err)
+	}
+	if len(staged) != 1 || staged[0] != "src/Foo.php" {
+		t.Fatalf("Expected only src/Foo.php staged, got %v", staged)
+	}
+
+	mirror, err := NewIndexMirror(repo, staged)
+	if err != nil {
+		t.Fatalf("NewIndexMirror failed: %v", err)
+	}
+	defer mirror.Close()
+
+	content, err := os.ReadFile(mirror.Paths()[0])
+	if err != nil || string(content) != "<?php\n// staged\n" {
+		t.Errorf("Expected the staged content in the mirror, got %q (%v)", content, err)
+	}
+
+	for _, config := range []string{"phpstan.neon", "src/.eslintrc"} {
+		if _, err := os.Stat(filepath.Join(mirror.Root, config)); err != nil {
+			t.Errorf("Expected %s to be mirrored: %v", config, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(mirror.Root, "other", "Other.php")); !os.IsNotExist(err) {
+		t.Error("Expected unstaged files to be left out of the mirror")
+	}
+
+	output := mirror.MapOutput(filepath.Join(mirror.Root, "src", "Foo.php") + ":2: error")
+	if want := filepath.Join(repo, "src", "Foo.php") + ":2: error"; output != want {
+		t.Errorf("MapOutput = %q, want %q", output, want)
+	}
+
+	root := mirror.Root
+	mirror.Close()
+	if _, err := os.Stat(root); !os.IsNotExist(err) {
+		t.Error("Expected Close to remove the mirror")
+	}
+}
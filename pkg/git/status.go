@@ -0,0 +1,88 @@
+package git
+
+// File status badges shown in the explorer
+const (
+	StatusModified  = "M"
+	StatusAdded     = "A"
+	StatusUntracked = "?"
+	StatusConflict  = "U"
+	StatusIgnored   = "!"
+)
+
+// IsRepo reports whether dir is inside a git work tree
+func IsRepo(dir string) bool {
+	out, err := run(dir, "rev-parse", "--is-inside-work-tree")
+	return err == nil && out == "true"
+}
+
+// TrackedFiles returns the repository-relative paths of all tracked files
+func TrackedFiles(dir string) ([]string, error) {
+	out, err := run(dir, "ls-files", "--full-name", "-z", "--cached", ":/")
+	if err != nil {
+		return nil, err
+	}
+	return splitNul(out), nil
+}
+
+// UntrackedFiles returns the repository-relative paths of untracked files
+// that aren't ignored. When ignored is true only the ignored ones are returned.
+func UntrackedFiles(dir string, ignored bool) ([]string, error) {
+	args := []string{"ls-files", "--full-name", "-z", "--others", "--exclude-standard"}
+	if ignored {
+		args = append(args, "--ignored")
+	}
+	out, err := run(dir, append(args, ":/")...)
+	if err != nil {
+		return nil, err
+	}
+	return splitNul(out), nil
+}
+
+// Status returns a status badge per repository-relative path for files
+// that differ from HEAD
+func Status(dir string) (map[string]string, error) {
+	out, err := run(dir, "status", "--porcelain=v1", "-z", "--untracked-files=all")
+	if err != nil {
+		return nil, err
+	}
+
+	status := make(map[string]string)
+	entries := splitNul(out)
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		x, y, path := entry[0], entry[1], entry[3:]
+
+		// Renames and copies are followed by their original path
+		if x == 'R' || x == 'C' {
+			i++
+		}
+
+		switch {
+		case x == 'U' || y == 'U' || (x == 'A' && y == 'A') || (x == 'D' && y == 'D'):
+			status[path] = StatusConflict
+		case x == '?':
+			status[path] = StatusUntracked
+		case x == '!':
+			status[path] = StatusIgnored
+		case x == 'A' || x == 'R' || x == 'C':
+			status[path] = StatusAdded
+		case x == 'M' || y == 'M' || y == 'T' || x == 'T':
+			status[path] = StatusModified
+		}
+	}
+
+	return status, nil
+}
+
+// StatusPriority orders badges so directories show their most relevant child status
+func StatusPriority(badge string) int {
+	for i, status := range []string{StatusIgnored, StatusUntracked, StatusAdded, StatusModified, StatusConflict} {
+		if status == badge {
+			return i + 1
+		}
+	}
+	return 0
+}
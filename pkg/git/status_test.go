@@ -0,0 +1,66 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	repo := initRepo(t, map[string]string{
+		".gitignore":   "build/\n",
+		"modified.php": "<?php\n",
+		"clean.php":    "<?php\n",
+	})
+
+	writeTestFile(t, filepath.Join(repo, "modified.php"), "<?php\n// changed\n")
+	writeTestFile(t, filepath.Join(repo, "added.php"), "<?php\n")
+	writeTestFile(t, filepath.Join(repo, "src", "new.php"), "<?php\n")
+	writeTestFile(t, filepath.Join(repo, "build", "out.php"), "<?php\n")
+	if _, err := run(repo, "add", "added.php"); err != nil {
+		t.Fatalf("git add failed: %v", err)
+	}
+
+	status, err := Status(repo)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"modified.php": StatusModified,
+		"added.php":    StatusAdded,
+		"src/new.php":  StatusUntracked,
+	}
+	for path, want := range expected {
+		if status[path] != want {
+			t.Errorf("Status of %s = %q, want %q", path, status[path], want)
+		}
+	}
+	if _, ok := status["clean.php"]; ok {
+		t.Error("Expected no status for an unchanged file")
+	}
+
+	untracked, err := UntrackedFiles(repo, false)
+	if err != nil {
+		t.Fatalf("UntrackedFiles failed: %v", err)
+	}
+	if len(untracked) != 1 || untracked[0] != "src/new.php" {
+		t.Errorf("Expected only src/new.php untracked, got %v", untracked)
+	}
+
+	ignored, err := UntrackedFiles(repo, true)
+	if err != nil {
+		t.Fatalf("UntrackedFiles failed: %v", err)
+	}
+	if len(ignored) != 1 || ignored[0] != "build/out.php" {
+		t.Errorf("Expected only build/out.php ignored, got %v", ignored)
+	}
+}
+
+func TestStatusPriority(t *testing.T) {
+	if StatusPriority(StatusConflict) <= StatusPriority(StatusModified) {
+		t.Error("Expected conflicts to outrank modifications")
+	}
+	if StatusPriority("") != 0 {
+		t.Error("Expected no priority for an empty status")
+	}
+}
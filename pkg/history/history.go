@@ -0,0 +1,195 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/linters"
+)
+
+// MaxRuns is the number of runs kept in the history file
+const MaxRuns = 500
+
+// LinterStats holds the summary of a single linter within a run
+type LinterStats struct {
+	Findings int           `json:"findings"`
+	Errors   int           `json:"errors"`
+	Warnings int           `json:"warnings"`
+	Success  bool          `json:"success"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Run represents a completed lint run
+type Run struct {
+	Timestamp time.Time              `json:"timestamp"`
+	Commit    string                 `json:"commit,omitempty"`
+	Duration  time.Duration          `json:"duration"`
+	Linters   map[string]LinterStats `json:"linters"`
+}
+
+// Total returns the number of findings across all linters in the run
+func (r Run) Total() int {
+	total := 0
+	for _, stats := range r.Linters {
+		total += stats.Findings
+	}
+	return total
+}
+
+// NewRun summarizes the given linter results into a run
+func NewRun(results []*linters.Result, commit string, duration time.Duration) Run {
+	run := Run{
+		Timestamp: time.Now(),
+		Commit:    commit,
+		Duration:  duration,
+		Linters:   make(map[string]LinterStats),
+	}
+
+	for _, result := range results {
+		findings := linters.ParseFindings(result)
+		errors, warnings := linters.CountBySeverity(findings)
+
+		// Partitions of a linter add up to its trend
+		stats, seen := run.Linters[result.Name]
+		run.Linters[result.Name] = LinterStats{
+			Findings: stats.Findings + len(findings),
+			Errors:   stats.Errors + errors,
+			Warnings: stats.Warnings + warnings,
+			Success:  result.Success && (!seen || stats.Success),
+			Duration: stats.Duration + result.Duration,
+		}
+	}
+
+	return run
+}
+
+// Store persists lint runs to a JSON file
+type Store struct {
+	path string
+}
+
+// NewStore creates a store backed by the given file
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultPath returns the history file location for the current repository
+func DefaultPath() string {
+	root, err := config.FindGitRoot()
+	if err != nil {
+		root = "."
+	}
+	return filepath.Join(root, ".lazylint", "history.json")
+}
+
+// Path returns the file the store reads from and writes to
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads all stored runs, oldest first. A missing file yields no runs.
+func (s *Store) Load() ([]Run, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	var runs []Run
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, fmt.Errorf("failed to parse history: %w", err)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].Timestamp.Before(runs[j].Timestamp)
+	})
+
+	return runs, nil
+}
+
+// Append adds a run to the store, dropping the oldest runs beyond MaxRuns
+func (s *Store) Append(run Run) error {
+	runs, err := s.Load()
+	if err != nil {
+		return err
+	}
+
+	runs = append(runs, run)
+	if len(runs) > MaxRuns {
+		runs = runs[len(runs)-MaxRuns:]
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(runs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode history: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	return nil
+}
+
+// ByCommit returns the most recent run for each commit, in order of first
+// appearance. Linters missing from that run keep their stats of the earlier
+// runs of the commit, so that a run of a single linter doesn't hide the others.
+func ByCommit(runs []Run) []Run {
+	var (
+		order  []string
+		latest = make(map[string]Run)
+	)
+
+	for _, run := range runs {
+		if run.Commit == "" {
+			continue
+		}
+		previous, ok := latest[run.Commit]
+		if !ok {
+			order = append(order, run.Commit)
+		}
+
+		merged := run
+		merged.Linters = make(map[string]LinterStats, len(previous.Linters)+len(run.Linters))
+		for name, stats := range previous.Linters {
+			merged.Linters[name] = stats
+		}
+		for name, stats := range run.Linters {
+			merged.Linters[name] = stats
+		}
+		latest[run.Commit] = merged
+	}
+
+	result := make([]Run, 0, len(order))
+	for _, commit := range order {
+		result = append(result, latest[commit])
+	}
+	return result
+}
+
+// LinterNames returns the sorted names of all linters appearing in the given runs
+func LinterNames(runs []Run) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, run := range runs {
+		for name := range run.Linters {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,71 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreAppendAndLoad(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), ".lazylint", "history.json"))
+
+	runs, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load on missing file failed: %v", err)
+	}
+	if len(runs) != 0 {
+		t.Fatalf("Expected no runs, got %d", len(runs))
+	}
+
+	base := time.Now()
+	for i := 0; i < 3; i++ {
+		run := Run{
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+			Commit:    "abc123",
+			Linters: map[string]LinterStats{
+				"phpstan": {Findings: 10 - i},
+			},
+		}
+		if err := store.Append(run); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	runs, err = store.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(runs) != 3 {
+		t.Fatalf("Expected 3 runs, got %d", len(runs))
+	}
+	if runs[2].Total() != 8 {
+		t.Errorf("Expected latest run to have 8 findings, got %d", runs[2].Total())
+	}
+}
+
+func TestByCommit(t *testing.T) {
+	runs := []Run{
+		{Commit: "aaa", Linters: map[string]LinterStats{"php": {Findings: 5}}},
+		{Commit: "bbb", Linters: map[string]LinterStats{"php": {Findings: 4}}},
+		{Commit: "aaa", Linters: map[string]LinterStats{"php": {Findings: 3}}},
+		{Commit: "", Linters: map[string]LinterStats{"php": {Findings: 1}}},
+	}
+
+	grouped := ByCommit(runs)
+	if len(grouped) != 2 {
+		t.Fatalf("Expected 2 commits, got %d", len(grouped))
+	}
+	if grouped[0].Commit != "aaa" || grouped[0].Total() != 3 {
+		t.Errorf("Expected latest run of aaa with 3 findings, got %s with %d", grouped[0].Commit, grouped[0].Total())
+	}
+	if grouped[1].Commit != "bbb" {
+		t.Errorf("Expected bbb second, got %s", grouped[1].Commit)
+	}
+
+	// A run of a single linter keeps the stats of the other linters
+	runs = append(runs, Run{Commit: "bbb", Linters: map[string]LinterStats{"phpstan": {Findings: 2}}})
+	grouped = ByCommit(runs)
+	if got := grouped[1].Linters; got["php"].Findings != 4 || got["phpstan"].Findings != 2 {
+		t.Errorf("Expected php and phpstan findings of bbb, got %+v", got)
+	}
+}
@@ -0,0 +1,137 @@
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// marker identifies hook scripts written by LazyLint
+const marker = "# Installed by lazylint"
+
+// chainedSuffix is appended to pre-existing hooks that LazyLint chains to
+const chainedSuffix = ".lazylint-chained"
+
+// Hook describes a git hook managed by LazyLint
+type Hook struct {
+	// Name is the git hook name, e.g. "pre-commit"
+	Name string
+
+	// Args are the arguments passed to lazylint by the hook
+	Args []string
+
+	// Stdin tells whether git passes data on stdin that must be forwarded
+	// to a chained hook
+	Stdin bool
+}
+
+// Default returns the hooks installed by "lazylint hooks install"
+func Default() []Hook {
+	return []Hook{
+		{Name: "pre-commit", Args: []string{"check", "--staged"}},
+		{Name: "pre-push", Args: []string{"check", "--against", "@{upstream}"}, Stdin: true},
+	}
+}
+
+// IsInstalled reports whether the hook file at path was written by LazyLint
+func IsInstalled(path string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && strings.Contains(string(data), marker)
+}
+
+// script renders the shell script for a hook
+func script(hook Hook, binary string) string {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	b.WriteString(marker + "; remove with \"lazylint hooks uninstall\"\n\n")
+
+	// Run the hook that was installed before LazyLint first
+	b.WriteString(fmt.Sprintf("chained=\"$(dirname \"$0\")/%s%s\"\n", hook.Name, chainedSuffix))
+	b.WriteString("if [ -x \"$chained\" ]; then\n")
+	if hook.Stdin {
+		b.WriteString("\tinput=$(cat)\n")
+		b.WriteString("\tprintf '%s\\n' \"$input\" | \"$chained\" \"$@\" || exit $?\n")
+	} else {
+		b.WriteString("\t\"$chained\" \"$@\" || exit $?\n")
+	}
+	b.WriteString("fi\n\n")
+
+	b.WriteString(fmt.Sprintf("exec %s %s\n", shellQuote(binary), strings.Join(quoteAll(hook.Args), " ")))
+	return b.String()
+}
+
+// Install writes the given hooks to dir. Existing hooks that weren't written
+// by LazyLint are kept and run before LazyLint. It returns the installed paths.
+func Install(dir, binary string, hooks []Hook) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	var installed []string
+	for _, hook := range hooks {
+		path := filepath.Join(dir, hook.Name)
+
+		// Chain to an existing hook instead of overwriting it
+		if _, err := os.Stat(path); err == nil && !IsInstalled(path) {
+			chained := path + chainedSuffix
+			if _, err := os.Stat(chained); err == nil {
+				return installed, fmt.Errorf("cannot chain %s: %s already exists", path, chained)
+			}
+			if err := os.Rename(path, chained); err != nil {
+				return installed, fmt.Errorf("failed to chain existing %s hook: %w", hook.Name, err)
+			}
+		}
+
+		if err := os.WriteFile(path, []byte(script(hook, binary)), 0755); err != nil {
+			return installed, fmt.Errorf("failed to write %s hook: %w", hook.Name, err)
+		}
+		installed = append(installed, path)
+	}
+
+	return installed, nil
+}
+
+// Uninstall removes the given hooks from dir and restores chained hooks.
+// Hooks not written by LazyLint are left untouched. It returns the removed paths.
+func Uninstall(dir string, hooks []Hook) ([]string, error) {
+	var removed []string
+	for _, hook := range hooks {
+		path := filepath.Join(dir, hook.Name)
+		if !IsInstalled(path) {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("failed to remove %s hook: %w", hook.Name, err)
+		}
+		removed = append(removed, path)
+
+		// Restore the hook we chained to
+		chained := path + chainedSuffix
+		if _, err := os.Stat(chained); err == nil {
+			if err := os.Rename(chained, path); err != nil {
+				return removed, fmt.Errorf("failed to restore %s hook: %w", hook.Name, err)
+			}
+		}
+	}
+
+	return removed, nil
+}
+
+// shellQuote quotes s for use in a POSIX shell script
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// quoteAll quotes every element of args for use in a shell script
+func quoteAll(args []string) []string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return quoted
+}
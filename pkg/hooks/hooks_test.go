@@ -0,0 +1,76 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallChainsAndUninstallRestores(t *testing.T) {
+	dir := t.TempDir()
+
+	// An existing hook that must be kept
+	existing := "#!/bin/sh\necho existing\n"
+	preCommit := filepath.Join(dir, "pre-commit")
+	if err := os.WriteFile(preCommit, []byte(existing), 0755); err != nil {
+		t.Fatalf("Failed to write existing hook: %v", err)
+	}
+
+	installed, err := Install(dir, "lazylint", Default())
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	if len(installed) != 2 {
+		t.Fatalf("Expected 2 hooks installed, got %d", len(installed))
+	}
+
+	data, err := os.ReadFile(preCommit)
+	if err != nil {
+		t.Fatalf("Failed to read hook: %v", err)
+	}
+	if !strings.Contains(string(data), "exec lazylint check --staged") {
+		t.Errorf("Unexpected pre-commit hook:\n%s", data)
+	}
+
+	chained, err := os.ReadFile(preCommit + chainedSuffix)
+	if err != nil || string(chained) != existing {
+		t.Fatalf("Expected existing hook to be chained, got %q (%v)", chained, err)
+	}
+
+	// Installing again must not chain our own hook
+	if _, err := Install(dir, "lazylint", Default()); err != nil {
+		t.Fatalf("Second install failed: %v", err)
+	}
+
+	removed, err := Uninstall(dir, Default())
+	if err != nil {
+		t.Fatalf("Uninstall failed: %v", err)
+	}
+	if len(removed) != 2 {
+		t.Errorf("Expected 2 hooks removed, got %d", len(removed))
+	}
+
+	restored, err := os.ReadFile(preCommit)
+	if err != nil || string(restored) != existing {
+		t.Errorf("Expected existing hook to be restored, got %q (%v)", restored, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pre-push")); !os.IsNotExist(err) {
+		t.Error("Expected pre-push hook to be removed")
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	testCases := map[string]string{
+		"lazylint":            "lazylint",
+		"/usr/local/bin/lint": "/usr/local/bin/lint",
+		"@{upstream}":         "'@{upstream}'",
+		"/path with/space":    "'/path with/space'",
+		"it's":                `'it'\''s'`,
+	}
+	for input, want := range testCases {
+		if got := shellQuote(input); got != want {
+			t.Errorf("shellQuote(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
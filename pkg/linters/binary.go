@@ -0,0 +1,209 @@
+package linters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// localBinDirs are the directories holding the project-local executables of
+// a linter, relative to a project root
+var localBinDirs = map[string][]string{
+	"phpstan":       {"vendor/bin"},
+	"phpcs":         {"vendor/bin"},
+	"eslint":        {"node_modules/.bin"},
+	"golangci-lint": {"bin"},
+}
+
+// configFiles are the configuration files of a linter, matched with
+// filepath.Match
+var configFiles = map[string][]string{
+	"phpstan":       {"phpstan.neon", "phpstan.neon.dist", "phpstan.dist.neon"},
+	"phpcs":         {"phpcs.xml", "phpcs.xml.dist", ".phpcs.xml", ".phpcs.xml.dist"},
+	"golangci-lint": {".golangci.yml", ".golangci.yaml", ".golangci.toml", ".golangci.json"},
+	"eslint":        {".eslintrc", ".eslintrc.*", "eslint.config.*"},
+}
+
+// Sources of an executable, see Binary
+const (
+	SourceConfig = "config"
+	SourcePath   = "PATH"
+)
+
+// Binary is the executable a linter runs
+type Binary struct {
+	// Path is the executable, empty when it can't be found
+	Path string
+
+	// Source tells how the executable was found: SourceConfig for an
+	// explicit path, the local bin directory such as "vendor/bin", or
+	// SourcePath
+	Source string
+
+	// Err explains why the executable can't be run
+	Err error
+}
+
+// commander is implemented by linters that run a configurable executable
+type commander interface {
+	Command() string
+}
+
+// FindBinary resolves the executable of the linter configured with path for
+// a run in dir, the current directory when empty. A bare command name is
+// looked up in the local bin directories of dir and its parents up to the
+// repository root, so that each sub-project uses its own version, and in
+// the PATH otherwise. Other paths are used as they are.
+func FindBinary(linter, path, dir string) Binary {
+	if path == "" {
+		return Binary{Err: errors.New("no executable configured")}
+	}
+
+	// Explicit paths are relative to the directory of the run
+	if strings.ContainsAny(path, `/\`) {
+		binary := Binary{Path: path, Source: SourceConfig}
+		if !filepath.IsAbs(path) && dir != "" {
+			path = filepath.Join(dir, path)
+		}
+		info, err := os.Stat(path)
+		switch {
+		case err != nil:
+			binary.Err = fmt.Errorf("the configured path %s does not exist", binary.Path)
+		case info.IsDir() || info.Mode()&0111 == 0:
+			binary.Err = fmt.Errorf("the configured path %s is not executable", binary.Path)
+		}
+		return binary
+	}
+
+	var found Binary
+	walkUp(dir, func(dir string) bool {
+		for _, bin := range localBinDirs[linter] {
+			candidate := filepath.Join(dir, filepath.FromSlash(bin), path)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				found = Binary{Path: candidate, Source: bin}
+				return true
+			}
+		}
+		return false
+	})
+	if found.Path != "" {
+		return found
+	}
+
+	resolved, err := exec.LookPath(path)
+	if err != nil {
+		searched := append(append([]string{}, localBinDirs[linter]...), "the PATH")
+		return Binary{Source: SourcePath, Err: fmt.Errorf("%s was not found in %s", path, strings.Join(searched, " or "))}
+	}
+	return Binary{Path: resolved, Source: SourcePath}
+}
+
+// resolveBinary returns the executable to run for the linter configured with
+// path from dir, see FindBinary. Executables that can't be found are left
+// for exec to report.
+func resolveBinary(linter, path, dir string) string {
+	if binary := FindBinary(linter, path, dir); binary.Err == nil {
+		return binary.Path
+	}
+	return path
+}
+
+// FindConfigFile returns the configuration file of the linter in dir, or an
+// empty string when there is none or the linter has no configuration file
+func FindConfigFile(linter, dir string) string {
+	for _, pattern := range configFiles[linter] {
+		matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && !info.IsDir() {
+				return match
+			}
+		}
+	}
+	return ""
+}
+
+// ConfigFileNames returns the names of the configuration files of the
+// linter, which may hold wildcards
+func ConfigFileNames(linter string) []string {
+	return configFiles[linter]
+}
+
+// walkUp calls visit for dir and its parents up to the repository root until
+// visit returns true
+func walkUp(dir string, visit func(dir string) bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return
+	}
+	for !visit(dir) {
+		// Stop at the repository root
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return
+		}
+		dir = parent
+	}
+}
+
+// AvailableIn reports whether the linter can run from the current directory
+// or from one of the given ones
+func AvailableIn(linter Linter, dirs []string) bool {
+	if linter.IsAvailable() {
+		return true
+	}
+	if c, ok := linter.(commander); ok {
+		for _, dir := range dirs {
+			if FindBinary(linter.Name(), c.Command(), dir).Err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LinterBinary resolves the executable of a linter for a run in dir, see
+// FindBinary. Linters that don't expose their executable have none.
+func LinterBinary(linter Linter, dir string) Binary {
+	c, ok := linter.(commander)
+	if !ok {
+		return Binary{Err: errors.New("the linter does not run an executable")}
+	}
+	return FindBinary(linter.Name(), c.Command(), dir)
+}
+
+// versionTimeout bounds the time an executable may take to print its version
+const versionTimeout = 10 * time.Second
+
+// VersionOutput runs the executable with --version in dir and returns the
+// first line it prints
+func VersionOutput(ctx context.Context, binary, dir string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, versionTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, binary, "--version")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+
+	var first string
+	for _, line := range strings.Split(StripANSI(string(out)), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			first = line
+			break
+		}
+	}
+	if err != nil {
+		if first != "" {
+			return "", fmt.Errorf("%s --version failed: %s", filepath.Base(binary), first)
+		}
+		return "", fmt.Errorf("%s --version failed: %w", filepath.Base(binary), err)
+	}
+	return first, nil
+}
@@ -0,0 +1,40 @@
+package linters
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveBinary(t *testing.T) {
+	root := t.TempDir()
+	os.Mkdir(filepath.Join(root, ".git"), 0755)
+
+	// The root and the admin frontend have their own ESLint
+	for _, dir := range []string{".", filepath.Join("frontend", "admin")} {
+		bin := filepath.Join(root, dir, "node_modules", ".bin")
+		os.MkdirAll(bin, 0755)
+		os.WriteFile(filepath.Join(bin, "eslint"), nil, 0755)
+	}
+	shop := filepath.Join(root, "frontend", "shop", "src")
+	os.MkdirAll(shop, 0755)
+
+	tests := []struct {
+		path, dir string
+		want      string
+	}{
+		{"eslint", filepath.Join(root, "frontend", "admin"), filepath.Join(root, "frontend", "admin", "node_modules", ".bin", "eslint")},
+		{"eslint", shop, filepath.Join(root, "node_modules", ".bin", "eslint")},
+		{"/opt/eslint/bin/eslint", shop, "/opt/eslint/bin/eslint"},
+	}
+	for _, tc := range tests {
+		if got := resolveBinary("eslint", tc.path, tc.dir); got != tc.want {
+			t.Errorf("resolveBinary(%s, %s) = %s, want %s", tc.path, tc.dir, got, tc.want)
+		}
+	}
+
+	// The search stops at the repository root
+	if got := resolveBinary("phpstan", "phpstan", shop); got != "phpstan" {
+		t.Errorf("resolveBinary(phpstan) = %s, want the PATH lookup", got)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 	"time"
 )
@@ -15,30 +14,14 @@ type ESLint struct {
 	path    string
 	args    []string
 	enabled bool
+
+	versioned
 }
 
 // NewESLint creates a new ESLint linter
 func NewESLint() *ESLint {
-	// Try to find git root directory
-	gitRoot, err := findGitRoot()
-	if err != nil {
-		gitRoot = ""
-	}
-
-	// Default path
-	eslintPath := "eslint"
-
-	// If git root is found, check for node_modules
-	if gitRoot != "" {
-		// Check if there's a local eslint in the project
-		localPath := filepath.Join(gitRoot, "node_modules", ".bin", "eslint")
-		if _, err := os.Stat(localPath); err == nil {
-			eslintPath = localPath
-		}
-	}
-
 	return &ESLint{
-		path:    eslintPath,
+		path:    "eslint",
 		args:    []string{"--format=stylish"},
 		enabled: true,
 	}
@@ -54,8 +37,8 @@ func (l *ESLint) Description() string {
 	return "JavaScript/TypeScript linter"
 }
 
-// Run executes the linter on the given target
-func (l *ESLint) Run(ctx context.Context, target string) (*Result, error) {
+// Run executes the linter on the given targets
+func (l *ESLint) Run(ctx context.Context, targets ...string) (*Result, error) {
 	if !l.enabled {
 		return &Result{
 			Name:      l.Name(),
@@ -65,19 +48,31 @@ func (l *ESLint) Run(ctx context.Context, target string) (*Result, error) {
 		}, nil
 	}
 
-	args := append([]string{}, l.args...)
-	if target != "" {
-		args = append(args, target)
+	// Refuse executables outside the version constraint of the config, and
+	// adapt the arguments to the major version
+	dir := WorkDir(ctx)
+	binary := resolveBinary(l.Name(), l.path, dir)
+	version, err := l.checkVersion(ctx, l.Name(), binary, dir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	args := eslintArgs(l.args, version)
+	for _, target := range targets {
+		if target != "" {
+			args = append(args, target)
+		}
 	}
 
 	start := time.Now()
-	cmd := exec.CommandContext(ctx, l.path, args...)
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = dir
 
 	var stdout, stderr strings.Builder
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err = cmd.Run()
 	duration := time.Since(start)
 
 	result := &Result{
@@ -93,13 +88,13 @@ func (l *ESLint) Run(ctx context.Context, target string) (*Result, error) {
 		if ctx.Err() == context.DeadlineExceeded {
 			return result, fmt.Errorf("command timed out after %s", duration)
 		}
-		
+
 		// Check if it's an exit code error (which is expected for these tools when they find issues)
 		if _, ok := err.(*exec.ExitError); ok {
 			result.Success = false
 			return result, nil
 		}
-		
+
 		return result, fmt.Errorf("command failed: %w", err)
 	}
 
@@ -109,8 +104,12 @@ func (l *ESLint) Run(ctx context.Context, target string) (*Result, error) {
 
 // IsAvailable checks if the linter is available
 func (l *ESLint) IsAvailable() bool {
-	_, err := exec.LookPath(l.path)
-	return err == nil
+	return FindBinary(l.Name(), l.path, "").Err == nil
+}
+
+// Command returns the configured executable
+func (l *ESLint) Command() string {
+	return l.path
 }
 
 // FileExtensions returns the file extensions this linter can process
@@ -123,7 +122,7 @@ func (l *ESLint) Configure(options map[string]interface{}) error {
 	if path, ok := options["path"].(string); ok {
 		l.path = path
 	}
-	
+
 	if args, ok := options["args"].([]string); ok {
 		l.args = args
 	} else if argsInterface, ok := options["args"].([]interface{}); ok {
@@ -135,10 +134,43 @@ func (l *ESLint) Configure(options map[string]interface{}) error {
 		}
 		l.args = args
 	}
-	
+
 	if enabled, ok := options["enabled"].(bool); ok {
 		l.enabled = enabled
 	}
-	
-	return nil
+
+	return l.configureVersion(options)
+}
+
+// eslintrcOptions are the options of the eslintrc config system, which ESLint
+// 9 rejects unless ESLINT_USE_FLAT_CONFIG=false, and whether they take a value
+var eslintrcOptions = map[string]bool{
+	"--ext": true, "--resolve-plugins-relative-to": true, "--rulesdir": true, "--ignore-path": true,
+}
+
+// eslintArgs returns the arguments for the given version of ESLint. Version 9
+// uses flat config: --no-eslintrc becomes --no-config-lookup and the other
+// eslintrc options are dropped, except --ext from version 9.21 which
+// supports it again.
+func eslintArgs(args []string, version Version) []string {
+	if version.Major < 9 || os.Getenv("ESLINT_USE_FLAT_CONFIG") == "false" {
+		return append([]string{}, args...)
+	}
+
+	var result []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, _, hasValue := strings.Cut(arg, "=")
+		takesValue, eslintrc := eslintrcOptions[name]
+		switch {
+		case arg == "--no-eslintrc":
+			result = append(result, "--no-config-lookup")
+		case !eslintrc || (name == "--ext" && version.Compare(Version{Major: 9, Minor: 21}) >= 0):
+			result = append(result, arg)
+		case takesValue && !hasValue:
+			// Drop the value given as the next argument too
+			i++
+		}
+	}
+	return result
 }
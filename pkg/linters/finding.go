@@ -0,0 +1,237 @@
+package linters
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Severity represents how serious a finding is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Finding represents a single issue reported by a linter
+type Finding struct {
+	Linter   string   `json:"linter"`
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Column   int      `json:"column,omitempty"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Rule     string   `json:"rule,omitempty"`
+}
+
+var (
+	// ansiPattern matches terminal color escape sequences
+	ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+	// compilerPattern matches "path:line[:col]: message" (golangci-lint, phpstan raw)
+	compilerPattern = regexp.MustCompile(`^(\S[^:]*):(\d+):(?:(\d+):)?\s*(.+)$`)
+
+	// eslintPattern matches the message lines of the ESLint stylish formatter
+	eslintPattern = regexp.MustCompile(`^\s+(\d+):(\d+)\s+(error|warning)\s+(.+?)(?:\s{2,}(\S+))?\s*$`)
+
+	// phpcsPattern matches the message lines of the PHPCS full report
+	phpcsPattern = regexp.MustCompile(`^\s*(\d+)\s*\|\s*(ERROR|WARNING)\s*\|\s*(?:\[.?\]\s*)?(.*)$`)
+
+	// phpcsContinuationPattern matches wrapped PHPCS message lines
+	phpcsContinuationPattern = regexp.MustCompile(`^\s*\|\s*\|\s*(.*)$`)
+
+	// phpstanHeaderPattern matches the file header of a PHPStan table
+	phpstanHeaderPattern = regexp.MustCompile(`^\s*Line\s+(\S.*?)\s*$`)
+
+	// phpstanLinePattern matches a message row of a PHPStan table
+	phpstanLinePattern = regexp.MustCompile(`^\s*(\d+)\s{2,}(\S.*?)\s*$`)
+
+	// phpLintPattern matches "php -l" parse errors
+	phpLintPattern = regexp.MustCompile(`^(?:PHP )?(Parse error|Fatal error|Warning|Deprecated):\s*(.+?) in (.+?) on line (\d+)`)
+
+	// golangciRulePattern matches the trailing "(linter)" of golangci-lint messages
+	golangciRulePattern = regexp.MustCompile(`\s*\(([\w-]+)\)$`)
+)
+
+// StripANSI removes terminal color escape sequences from the given text
+func StripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// ParseFindings extracts structured findings from the output of a linter.
+// Output that doesn't match any known format yields no findings.
+func ParseFindings(result *Result) []Finding {
+	if result == nil {
+		return nil
+	}
+
+	output := StripANSI(result.Output)
+	if result.Error != "" {
+		output += "\n" + StripANSI(result.Error)
+	}
+
+	var (
+		findings    []Finding
+		currentFile string
+		last        *Finding
+	)
+
+	for _, raw := range strings.Split(output, "\n") {
+		line := strings.TrimRight(raw, "\r ")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			last = nil
+			continue
+		}
+
+		// PHPCS file header
+		if strings.HasPrefix(trimmed, "FILE: ") {
+			currentFile = strings.TrimSpace(strings.TrimPrefix(trimmed, "FILE: "))
+			last = nil
+			continue
+		}
+
+		// PHPStan table header
+		if m := phpstanHeaderPattern.FindStringSubmatch(line); m != nil {
+			currentFile = m[1]
+			last = nil
+			continue
+		}
+
+		// Table separators carry no information
+		if strings.Trim(trimmed, "- +") == "" {
+			continue
+		}
+
+		if m := phpLintPattern.FindStringSubmatch(trimmed); m != nil {
+			severity := SeverityError
+			if m[1] == "Warning" || m[1] == "Deprecated" {
+				severity = SeverityWarning
+			}
+			findings = append(findings, Finding{
+				Linter:   result.Name,
+				File:     m[3],
+				Line:     atoi(m[4]),
+				Severity: severity,
+				Message:  m[2],
+			})
+			last = nil
+			continue
+		}
+
+		if currentFile != "" {
+			if m := eslintPattern.FindStringSubmatch(line); m != nil {
+				findings = append(findings, Finding{
+					Linter:   result.Name,
+					File:     currentFile,
+					Line:     atoi(m[1]),
+					Column:   atoi(m[2]),
+					Severity: Severity(m[3]),
+					Message:  m[4],
+					Rule:     m[5],
+				})
+				last = nil
+				continue
+			}
+
+			if m := phpcsPattern.FindStringSubmatch(line); m != nil {
+				findings = append(findings, Finding{
+					Linter:   result.Name,
+					File:     currentFile,
+					Line:     atoi(m[1]),
+					Severity: Severity(strings.ToLower(m[2])),
+					Message:  strings.TrimSpace(m[3]),
+				})
+				last = &findings[len(findings)-1]
+				continue
+			}
+
+			if m := phpcsContinuationPattern.FindStringSubmatch(line); m != nil {
+				if last != nil {
+					last.Message = strings.TrimSpace(last.Message + " " + m[1])
+				}
+				continue
+			}
+
+			if m := phpstanLinePattern.FindStringSubmatch(line); m != nil {
+				findings = append(findings, Finding{
+					Linter:   result.Name,
+					File:     currentFile,
+					Line:     atoi(m[1]),
+					Severity: SeverityError,
+					Message:  m[2],
+				})
+				last = &findings[len(findings)-1]
+				continue
+			}
+		}
+
+		if m := compilerPattern.FindStringSubmatch(trimmed); m != nil {
+			finding := Finding{
+				Linter:   result.Name,
+				File:     m[1],
+				Line:     atoi(m[2]),
+				Column:   atoi(m[3]),
+				Severity: SeverityError,
+				Message:  m[4],
+			}
+			if rule := golangciRulePattern.FindStringSubmatch(finding.Message); rule != nil {
+				finding.Rule = rule[1]
+				finding.Message = strings.TrimSuffix(finding.Message, rule[0])
+			}
+			if strings.HasPrefix(strings.ToLower(finding.Message), "warning") {
+				finding.Severity = SeverityWarning
+			}
+			findings = append(findings, finding)
+			last = nil
+			continue
+		}
+
+		// ESLint stylish prints the file path on a line of its own
+		if !strings.HasPrefix(line, " ") && !strings.ContainsAny(trimmed, " \t") && strings.Contains(trimmed, ".") {
+			currentFile = trimmed
+			last = nil
+			continue
+		}
+
+		// Wrapped PHPStan messages are indented past the line column
+		if last != nil && strings.HasPrefix(line, "  ") {
+			last.Message = last.Message + " " + trimmed
+		}
+	}
+
+	// Paths printed by a linter running in another directory are relative to it
+	if result.Dir != "" {
+		for i := range findings {
+			if !filepath.IsAbs(findings[i].File) {
+				findings[i].File = filepath.Join(result.Dir, findings[i].File)
+			}
+		}
+	}
+
+	return findings
+}
+
+// CountBySeverity returns the number of errors and warnings in the given findings
+func CountBySeverity(findings []Finding) (errors, warnings int) {
+	for _, f := range findings {
+		if f.Severity == SeverityWarning {
+			warnings++
+		} else {
+			errors++
+		}
+	}
+	return errors, warnings
+}
+
+// atoi converts a numeric string, returning 0 for empty or invalid input
+func atoi(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
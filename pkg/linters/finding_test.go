@@ -0,0 +1,113 @@
+package linters
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseFindings(t *testing.T) {
+	testCases := []struct {
+		name     string
+		linter   string
+		output   string
+		expected []Finding
+	}{
+		{
+			name:   "golangci-lint",
+			linter: "golangci-lint",
+			output: "pkg/foo.go:12:5: \x1b[1mError return value is not checked\x1b[0m (errcheck)\n",
+			expected: []Finding{
+				{File: "pkg/foo.go", Line: 12, Column: 5, Severity: SeverityError, Message: "Error return value is not checked", Rule: "errcheck"},
+			},
+		},
+		{
+			name:   "eslint stylish",
+			linter: "eslint",
+			output: "\n/app/src/index.js\n  3:7   error    'x' is assigned a value but never used  no-unused-vars\n  9:1   warning  Unexpected console statement            no-console\n\n✖ 2 problems (1 error, 1 warning)\n",
+			expected: []Finding{
+				{File: "/app/src/index.js", Line: 3, Column: 7, Severity: SeverityError, Message: "'x' is assigned a value but never used", Rule: "no-unused-vars"},
+				{File: "/app/src/index.js", Line: 9, Column: 1, Severity: SeverityWarning, Message: "Unexpected console statement", Rule: "no-console"},
+			},
+		},
+		{
+			name:   "phpcs full report",
+			linter: "phpcs",
+			output: "\nFILE: /app/src/Foo.php\n----------------------------------------------------------------------\nFOUND 1 ERROR AND 1 WARNING AFFECTING 2 LINES\n----------------------------------------------------------------------\n  4 | ERROR   | [x] Opening brace should be on a new line\n 10 | WARNING | [ ] Line exceeds 120 characters; contains 130\n    |         |     characters\n----------------------------------------------------------------------\n",
+			expected: []Finding{
+				{File: "/app/src/Foo.php", Line: 4, Severity: SeverityError, Message: "Opening brace should be on a new line"},
+				{File: "/app/src/Foo.php", Line: 10, Severity: SeverityWarning, Message: "Line exceeds 120 characters; contains 130 characters"},
+			},
+		},
+		{
+			name:   "phpstan table",
+			linter: "phpstan",
+			output: " ------ ---------------------------------------\n  Line   src/Foo.php\n ------ ---------------------------------------\n  12     Call to an undefined method Foo::bar().\n  30     Method Foo::baz() has no return type\n         specified.\n ------ ---------------------------------------\n\n [ERROR] Found 2 errors\n",
+			expected: []Finding{
+				{File: "src/Foo.php", Line: 12, Severity: SeverityError, Message: "Call to an undefined method Foo::bar()."},
+				{File: "src/Foo.php", Line: 30, Severity: SeverityError, Message: "Method Foo::baz() has no return type specified."},
+			},
+		},
+		{
+			name:   "php syntax check",
+			linter: "php",
+			output: "PHP Parse error:  syntax error, unexpected '}' in src/Bar.php on line 7\nErrors parsing src/Bar.php\n",
+			expected: []Finding{
+				{File: "src/Bar.php", Line: 7, Severity: SeverityError, Message: "syntax error, unexpected '}'"},
+			},
+		},
+		{
+			name:     "clean output",
+			linter:   "php",
+			output:   "No syntax errors detected in src/Bar.php\n",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			findings := ParseFindings(&Result{Name: tc.linter, Output: tc.output})
+			if len(findings) != len(tc.expected) {
+				t.Fatalf("Expected %d findings, got %d: %+v", len(tc.expected), len(findings), findings)
+			}
+			for i, want := range tc.expected {
+				want.Linter = tc.linter
+				if findings[i] != want {
+					t.Errorf("Finding %d mismatch:\n got  %+v\n want %+v", i, findings[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestCountBySeverity(t *testing.T) {
+	findings := []Finding{
+		{Severity: SeverityError},
+		{Severity: SeverityWarning},
+		{Severity: SeverityError},
+	}
+
+	errors, warnings := CountBySeverity(findings)
+	if errors != 2 || warnings != 1 {
+		t.Errorf("Expected 2 errors and 1 warning, got %d and %d", errors, warnings)
+	}
+}
+
+func TestParseFindingsInDir(t *testing.T) {
+	dir := filepath.Join(string(filepath.Separator), "repo", "services", "legacy")
+	result := &Result{
+		Name:   "golangci-lint",
+		Output: "src/user.go:12:3: unused variable (unused)\n/repo/main.go:4:1: missing return\n",
+		Dir:    dir,
+	}
+
+	findings := ParseFindings(result)
+	if len(findings) != 2 {
+		t.Fatalf("Expected 2 findings, got %+v", findings)
+	}
+	if want := filepath.Join(dir, "src", "user.go"); findings[0].File != want {
+		t.Errorf("Expected relative paths below %s, got %s", dir, findings[0].File)
+	}
+	if findings[1].File != "/repo/main.go" {
+		t.Errorf("Expected absolute paths to be kept, got %s", findings[1].File)
+	}
+}
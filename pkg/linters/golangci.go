@@ -3,9 +3,7 @@ package linters
 import (
 	"context"
 	"fmt"
-	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 	"time"
 )
@@ -15,30 +13,14 @@ type GolangCI struct {
 	path    string
 	args    []string
 	enabled bool
+
+	versioned
 }
 
 // NewGolangCI creates a new GolangCI linter
 func NewGolangCI() *GolangCI {
-	// Try to find git root directory
-	gitRoot, err := findGitRoot()
-	if err != nil {
-		gitRoot = ""
-	}
-
-	// Default path
-	golangciPath := "golangci-lint"
-
-	// If git root is found, check for Go tools
-	if gitRoot != "" {
-		// Check if there's a local golangci-lint in the project
-		localPath := filepath.Join(gitRoot, "bin", "golangci-lint")
-		if _, err := os.Stat(localPath); err == nil {
-			golangciPath = localPath
-		}
-	}
-
 	return &GolangCI{
-		path:    golangciPath,
+		path:    "golangci-lint",
 		args:    []string{"run", "--out-format=colored-line-number"},
 		enabled: true,
 	}
@@ -54,8 +36,8 @@ func (l *GolangCI) Description() string {
 	return "Fast Go linters runner"
 }
 
-// Run executes the linter on the given target
-func (l *GolangCI) Run(ctx context.Context, target string) (*Result, error) {
+// Run executes the linter on the given targets
+func (l *GolangCI) Run(ctx context.Context, targets ...string) (*Result, error) {
 	if !l.enabled {
 		return &Result{
 			Name:      l.Name(),
@@ -65,19 +47,31 @@ func (l *GolangCI) Run(ctx context.Context, target string) (*Result, error) {
 		}, nil
 	}
 
-	args := append([]string{}, l.args...)
-	if target != "" {
-		args = append(args, target)
+	// Refuse executables outside the version constraint of the config, and
+	// adapt the arguments to the major version
+	dir := WorkDir(ctx)
+	binary := resolveBinary(l.Name(), l.path, dir)
+	version, err := l.checkVersion(ctx, l.Name(), binary, dir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	args := golangciArgs(l.args, version)
+	for _, target := range targets {
+		if target != "" {
+			args = append(args, target)
+		}
 	}
 
 	start := time.Now()
-	cmd := exec.CommandContext(ctx, l.path, args...)
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = dir
 
 	var stdout, stderr strings.Builder
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err = cmd.Run()
 	duration := time.Since(start)
 
 	result := &Result{
@@ -93,13 +87,13 @@ func (l *GolangCI) Run(ctx context.Context, target string) (*Result, error) {
 		if ctx.Err() == context.DeadlineExceeded {
 			return result, fmt.Errorf("command timed out after %s", duration)
 		}
-		
+
 		// Check if it's an exit code error (which is expected for these tools when they find issues)
 		if _, ok := err.(*exec.ExitError); ok {
 			result.Success = false
 			return result, nil
 		}
-		
+
 		return result, fmt.Errorf("command failed: %w", err)
 	}
 
@@ -109,8 +103,12 @@ func (l *GolangCI) Run(ctx context.Context, target string) (*Result, error) {
 
 // IsAvailable checks if the linter is available
 func (l *GolangCI) IsAvailable() bool {
-	_, err := exec.LookPath(l.path)
-	return err == nil
+	return FindBinary(l.Name(), l.path, "").Err == nil
+}
+
+// Command returns the configured executable
+func (l *GolangCI) Command() string {
+	return l.path
 }
 
 // FileExtensions returns the file extensions this linter can process
@@ -123,7 +121,7 @@ func (l *GolangCI) Configure(options map[string]interface{}) error {
 	if path, ok := options["path"].(string); ok {
 		l.path = path
 	}
-	
+
 	if args, ok := options["args"].([]string); ok {
 		l.args = args
 	} else if argsInterface, ok := options["args"].([]interface{}); ok {
@@ -135,10 +133,51 @@ func (l *GolangCI) Configure(options map[string]interface{}) error {
 		}
 		l.args = args
 	}
-	
+
 	if enabled, ok := options["enabled"].(bool); ok {
 		l.enabled = enabled
 	}
-	
-	return nil
+
+	return l.configureVersion(options)
+}
+
+// golangciFormats are the output formats of golangci-lint version 2, which
+// replaced --out-format=<format> by --output.<format>.path=<file>
+var golangciFormats = map[string]bool{
+	"text": true, "json": true, "tab": true, "html": true, "checkstyle": true,
+	"code-climate": true, "junit-xml": true, "teamcity": true, "sarif": true,
+}
+
+// golangciArgs returns the arguments for the given version of golangci-lint,
+// translating the --out-format flag of version 1 for later versions. The
+// line-number formats of version 1 are the text format of version 2.
+func golangciArgs(args []string, version Version) []string {
+	if version.Major < 2 {
+		return append([]string{}, args...)
+	}
+
+	var result []string
+	for _, arg := range args {
+		format, ok := strings.CutPrefix(arg, "--out-format=")
+		if !ok {
+			result = append(result, arg)
+			continue
+		}
+
+		colors := strings.HasPrefix(format, "colored-")
+		format = strings.TrimPrefix(format, "colored-")
+		if format == "line-number" {
+			format = "text"
+		}
+		if !golangciFormats[format] {
+			// Formats without an equivalent, such as github-actions, keep the default output
+			continue
+		}
+
+		result = append(result, "--output."+format+".path=stdout")
+		if format == "text" || format == "tab" {
+			result = append(result, fmt.Sprintf("--output.%s.colors=%t", format, colors))
+		}
+	}
+	return result
 }
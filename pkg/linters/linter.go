@@ -2,6 +2,9 @@ package linters
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"sort"
 	"time"
 )
 
@@ -13,32 +16,68 @@ type Result struct {
 	Error     string
 	Duration  time.Duration
 	Timestamp time.Time
+
+	// Partition labels a run on part of the targets with per-directory
+	// options, e.g. "services/legacy/**", empty for a run of the linter as
+	// configured
+	Partition string
+
+	// Dir is the directory the linter ran in when it differs from the current
+	// one. Relative paths in the output are relative to it.
+	Dir string
+}
+
+// Label returns the name of the linter with the partition of the run, if any
+func (r *Result) Label() string {
+	if r.Partition == "" {
+		return r.Name
+	}
+	return r.Name + " (" + r.Partition + ")"
 }
 
 // Linter defines the interface that all linters must implement
 type Linter interface {
 	// Name returns the name of the linter
 	Name() string
-	
+
 	// Description returns a short description of the linter
 	Description() string
-	
-	// Run executes the linter on the given target
-	Run(ctx context.Context, target string) (*Result, error)
-	
+
+	// Run executes the linter on the given targets (files or directories).
+	// Without targets the linter runs on its default scope.
+	Run(ctx context.Context, targets ...string) (*Result, error)
+
 	// IsAvailable checks if the linter is available in the current environment
 	IsAvailable() bool
-	
+
 	// FileExtensions returns the file extensions this linter can process
 	FileExtensions() []string
-	
+
 	// Configure configures the linter with the given options
 	Configure(options map[string]interface{}) error
 }
 
+// workDirKey is the context key holding the working directory of a run
+type workDirKey struct{}
+
+// WithWorkDir returns a context that makes linters run in the given directory
+func WithWorkDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, workDirKey{}, dir)
+}
+
+// WorkDir returns the directory set with WithWorkDir, or an empty string
+// to run in the current directory
+func WorkDir(ctx context.Context) string {
+	dir, _ := ctx.Value(workDirKey{}).(string)
+	return dir
+}
+
 // Registry manages the available linters
 type Registry struct {
 	linters map[string]Linter
+
+	// dirs are the project directories linters may run from, see SetDirs
+	dirs []string
 }
 
 // NewRegistry creates a new linter registry
@@ -68,17 +107,38 @@ func (r *Registry) GetAll() []Linter {
 	return result
 }
 
+// SetDirs sets the project directories linters may run from. Linters
+// installed in one of them only, e.g. in its node_modules, are available.
+func (r *Registry) SetDirs(dirs []string) {
+	r.dirs = dirs
+}
+
 // GetAvailable returns all available linters
 func (r *Registry) GetAvailable() []Linter {
 	var result []Linter
 	for _, linter := range r.linters {
-		if linter.IsAvailable() {
+		if AvailableIn(linter, r.dirs) {
 			result = append(result, linter)
 		}
 	}
 	return result
 }
 
+// GetEnabled returns the available linters for which enabled returns true,
+// sorted by name, e.g. GetEnabled(cfg.LinterEnabled)
+func (r *Registry) GetEnabled(enabled func(name string) bool) []Linter {
+	var result []Linter
+	for _, linter := range r.GetAvailable() {
+		if enabled(linter.Name()) {
+			result = append(result, linter)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name() < result[j].Name()
+	})
+	return result
+}
+
 // GetForExtension returns all linters that can process files with the given extension
 func (r *Registry) GetForExtension(ext string) []Linter {
 	var result []Linter
@@ -92,3 +152,24 @@ func (r *Registry) GetForExtension(ext string) []Linter {
 	}
 	return result
 }
+
+// FilterTargets returns the targets the given linter can process.
+// Directories are always kept, files only when their extension matches.
+func FilterTargets(linter Linter, targets []string) []string {
+	var result []string
+	for _, target := range targets {
+		if info, err := os.Stat(target); err == nil && info.IsDir() {
+			result = append(result, target)
+			continue
+		}
+
+		ext := filepath.Ext(target)
+		for _, e := range linter.FileExtensions() {
+			if e == ext {
+				result = append(result, target)
+				break
+			}
+		}
+	}
+	return result
+}
@@ -13,6 +13,8 @@ type PHP struct {
 	path    string
 	args    []string
 	enabled bool
+
+	versioned
 }
 
 // NewPHP creates a new PHP linter
@@ -56,11 +58,11 @@ func (l *PHP) Configure(options map[string]interface{}) error {
 		l.enabled = enabled
 	}
 
-	return nil
+	return l.configureVersion(options)
 }
 
-// Run executes the linter on the given target
-func (l *PHP) Run(ctx context.Context, target string) (*Result, error) {
+// Run executes the linter on the given targets
+func (l *PHP) Run(ctx context.Context, targets ...string) (*Result, error) {
 	if !l.enabled {
 		return &Result{
 			Name:      l.Name(),
@@ -70,13 +72,23 @@ func (l *PHP) Run(ctx context.Context, target string) (*Result, error) {
 		}, nil
 	}
 
+	// Refuse executables outside the version constraint of the config
+	dir := WorkDir(ctx)
+	binary := resolveBinary(l.Name(), l.path, dir)
+	if _, err := l.checkVersion(ctx, l.Name(), binary, dir, false); err != nil {
+		return nil, err
+	}
+
 	args := append([]string{}, l.args...)
-	if target != "" {
-		args = append(args, target)
+	for _, target := range targets {
+		if target != "" {
+			args = append(args, target)
+		}
 	}
 
 	start := time.Now()
-	cmd := exec.CommandContext(ctx, l.path, args...)
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = dir
 
 	var stdout, stderr strings.Builder
 	cmd.Stdout = &stdout
@@ -114,8 +126,12 @@ func (l *PHP) Run(ctx context.Context, target string) (*Result, error) {
 
 // IsAvailable checks if the linter is available
 func (l *PHP) IsAvailable() bool {
-	_, err := exec.LookPath(l.path)
-	return err == nil
+	return FindBinary(l.Name(), l.path, "").Err == nil
+}
+
+// Command returns the configured executable
+func (l *PHP) Command() string {
+	return l.path
 }
 
 // FileExtensions returns the file extensions this linter can process
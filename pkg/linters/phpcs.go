@@ -3,9 +3,7 @@ package linters
 import (
 	"context"
 	"fmt"
-	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 	"time"
 )
@@ -15,30 +13,14 @@ type PHPCS struct {
 	path    string
 	args    []string
 	enabled bool
+
+	versioned
 }
 
 // NewPHPCS creates a new PHPCS linter
 func NewPHPCS() *PHPCS {
-	// Try to find git root directory
-	gitRoot, err := findGitRoot()
-	if err != nil {
-		gitRoot = ""
-	}
-
-	// Default path
-	phpcsPath := "phpcs"
-
-	// If git root is found, check vendor/bin directory
-	if gitRoot != "" {
-		vendorBin := filepath.Join(gitRoot, "vendor", "bin")
-		vendorPath := filepath.Join(vendorBin, "phpcs")
-		if _, err := os.Stat(vendorPath); err == nil {
-			phpcsPath = vendorPath
-		}
-	}
-
 	return &PHPCS{
-		path:    phpcsPath,
+		path:    "phpcs",
 		args:    []string{"--standard=PSR12"},
 		enabled: true,
 	}
@@ -54,8 +36,8 @@ func (l *PHPCS) Description() string {
 	return "PHP_CodeSniffer detects violations of a defined coding standard"
 }
 
-// Run executes the linter on the given target
-func (l *PHPCS) Run(ctx context.Context, target string) (*Result, error) {
+// Run executes the linter on the given targets
+func (l *PHPCS) Run(ctx context.Context, targets ...string) (*Result, error) {
 	if !l.enabled {
 		return &Result{
 			Name:      l.Name(),
@@ -65,19 +47,31 @@ func (l *PHPCS) Run(ctx context.Context, target string) (*Result, error) {
 		}, nil
 	}
 
-	args := append([]string{}, l.args...)
-	if target != "" {
-		args = append(args, target)
+	// Refuse executables outside the version constraint of the config, and
+	// adapt the arguments to the major version
+	dir := WorkDir(ctx)
+	binary := resolveBinary(l.Name(), l.path, dir)
+	version, err := l.checkVersion(ctx, l.Name(), binary, dir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	args := phpcsArgs(l.args, version)
+	for _, target := range targets {
+		if target != "" {
+			args = append(args, target)
+		}
 	}
 
 	start := time.Now()
-	cmd := exec.CommandContext(ctx, l.path, args...)
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = dir
 
 	var stdout, stderr strings.Builder
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err = cmd.Run()
 	duration := time.Since(start)
 
 	result := &Result{
@@ -93,13 +87,13 @@ func (l *PHPCS) Run(ctx context.Context, target string) (*Result, error) {
 		if ctx.Err() == context.DeadlineExceeded {
 			return result, fmt.Errorf("command timed out after %s", duration)
 		}
-		
+
 		// Check if it's an exit code error (which is expected for these tools when they find issues)
 		if _, ok := err.(*exec.ExitError); ok {
 			result.Success = false
 			return result, nil
 		}
-		
+
 		return result, fmt.Errorf("command failed: %w", err)
 	}
 
@@ -109,8 +103,12 @@ func (l *PHPCS) Run(ctx context.Context, target string) (*Result, error) {
 
 // IsAvailable checks if the linter is available
 func (l *PHPCS) IsAvailable() bool {
-	_, err := exec.LookPath(l.path)
-	return err == nil
+	return FindBinary(l.Name(), l.path, "").Err == nil
+}
+
+// Command returns the configured executable
+func (l *PHPCS) Command() string {
+	return l.path
 }
 
 // FileExtensions returns the file extensions this linter can process
@@ -123,7 +121,7 @@ func (l *PHPCS) Configure(options map[string]interface{}) error {
 	if path, ok := options["path"].(string); ok {
 		l.path = path
 	}
-	
+
 	if args, ok := options["args"].([]string); ok {
 		l.args = args
 	} else if argsInterface, ok := options["args"].([]interface{}); ok {
@@ -135,10 +133,32 @@ func (l *PHPCS) Configure(options map[string]interface{}) error {
 		}
 		l.args = args
 	}
-	
+
 	if enabled, ok := options["enabled"].(bool); ok {
 		l.enabled = enabled
 	}
-	
-	return nil
+
+	return l.configureVersion(options)
+}
+
+// phpcsArgs returns the arguments for the given version of PHPCS. Version 4
+// dropped the tokenizer of --extensions, e.g. "inc/php" is "inc".
+func phpcsArgs(args []string, version Version) []string {
+	result := append([]string{}, args...)
+	if version.Major < 4 {
+		return result
+	}
+
+	for i, arg := range result {
+		list, ok := strings.CutPrefix(arg, "--extensions=")
+		if !ok {
+			continue
+		}
+		extensions := strings.Split(list, ",")
+		for j, extension := range extensions {
+			extensions[j], _, _ = strings.Cut(extension, "/")
+		}
+		result[i] = "--extensions=" + strings.Join(extensions, ",")
+	}
+	return result
 }
@@ -3,9 +3,7 @@ package linters
 import (
 	"context"
 	"fmt"
-	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 	"time"
 )
@@ -15,30 +13,14 @@ type PHPStan struct {
 	path    string
 	args    []string
 	enabled bool
+
+	versioned
 }
 
 // NewPHPStan creates a new PHPStan linter
 func NewPHPStan() *PHPStan {
-	// Try to find git root directory
-	gitRoot, err := findGitRoot()
-	if err != nil {
-		gitRoot = ""
-	}
-
-	// Default path
-	phpstanPath := "phpstan"
-
-	// If git root is found, check vendor/bin directory
-	if gitRoot != "" {
-		vendorBin := filepath.Join(gitRoot, "vendor", "bin")
-		vendorPath := filepath.Join(vendorBin, "phpstan")
-		if _, err := os.Stat(vendorPath); err == nil {
-			phpstanPath = vendorPath
-		}
-	}
-
 	return &PHPStan{
-		path:    phpstanPath,
+		path:    "phpstan",
 		args:    []string{"analyse", "--level=5"},
 		enabled: true,
 	}
@@ -54,8 +36,8 @@ func (l *PHPStan) Description() string {
 	return "PHP Static Analysis Tool"
 }
 
-// Run executes the linter on the given target
-func (l *PHPStan) Run(ctx context.Context, target string) (*Result, error) {
+// Run executes the linter on the given targets
+func (l *PHPStan) Run(ctx context.Context, targets ...string) (*Result, error) {
 	if !l.enabled {
 		return &Result{
 			Name:      l.Name(),
@@ -65,19 +47,31 @@ func (l *PHPStan) Run(ctx context.Context, target string) (*Result, error) {
 		}, nil
 	}
 
-	args := append([]string{}, l.args...)
-	if target != "" {
-		args = append(args, target)
+	// Refuse executables outside the version constraint of the config, and
+	// adapt the arguments to the major version
+	dir := WorkDir(ctx)
+	binary := resolveBinary(l.Name(), l.path, dir)
+	version, err := l.checkVersion(ctx, l.Name(), binary, dir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	args := phpstanArgs(l.args, version)
+	for _, target := range targets {
+		if target != "" {
+			args = append(args, target)
+		}
 	}
 
 	start := time.Now()
-	cmd := exec.CommandContext(ctx, l.path, args...)
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = dir
 
 	var stdout, stderr strings.Builder
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err = cmd.Run()
 	duration := time.Since(start)
 
 	result := &Result{
@@ -93,13 +87,13 @@ func (l *PHPStan) Run(ctx context.Context, target string) (*Result, error) {
 		if ctx.Err() == context.DeadlineExceeded {
 			return result, fmt.Errorf("command timed out after %s", duration)
 		}
-		
+
 		// Check if it's an exit code error (which is expected for these tools when they find issues)
 		if _, ok := err.(*exec.ExitError); ok {
 			result.Success = false
 			return result, nil
 		}
-		
+
 		return result, fmt.Errorf("command failed: %w", err)
 	}
 
@@ -109,8 +103,12 @@ func (l *PHPStan) Run(ctx context.Context, target string) (*Result, error) {
 
 // IsAvailable checks if the linter is available
 func (l *PHPStan) IsAvailable() bool {
-	_, err := exec.LookPath(l.path)
-	return err == nil
+	return FindBinary(l.Name(), l.path, "").Err == nil
+}
+
+// Command returns the configured executable
+func (l *PHPStan) Command() string {
+	return l.path
 }
 
 // FileExtensions returns the file extensions this linter can process
@@ -123,7 +121,7 @@ func (l *PHPStan) Configure(options map[string]interface{}) error {
 	if path, ok := options["path"].(string); ok {
 		l.path = path
 	}
-	
+
 	if args, ok := options["args"].([]string); ok {
 		l.args = args
 	} else if argsInterface, ok := options["args"].([]interface{}); ok {
@@ -135,42 +133,29 @@ func (l *PHPStan) Configure(options map[string]interface{}) error {
 		}
 		l.args = args
 	}
-	
+
 	if enabled, ok := options["enabled"].(bool); ok {
 		l.enabled = enabled
 	}
-	
-	return nil
-}
 
-// Helper function to find git root
-func findGitRoot() (string, error) {
-	// Try to find git root using git command
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
-	output, err := cmd.Output()
-	if err == nil {
-		return strings.TrimSpace(string(output)), nil
-	}
+	return l.configureVersion(options)
+}
 
-	// Fallback: manually search for .git directory
-	dir, err := os.Getwd()
-	if err != nil {
-		return "", err
+// phpstanArgs returns the arguments for the given version of PHPStan. Level
+// 10 came with version 2, version 1 runs its highest level instead.
+func phpstanArgs(args []string, version Version) []string {
+	result := append([]string{}, args...)
+	if version.Major != 1 {
+		return result
 	}
 
-	for {
-		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
-			return dir, nil
+	for i, arg := range result {
+		switch {
+		case arg == "--level=10":
+			result[i] = "--level=max"
+		case (arg == "--level" || arg == "-l") && i+1 < len(result) && result[i+1] == "10":
+			result[i+1] = "max"
 		}
-
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			// We've reached the root directory
-			break
-		}
-		dir = parent
 	}
-
-	// If we can't find a git root, return the current directory
-	return os.Getwd()
+	return result
 }
@@ -1,7 +1,5 @@
 package linters
 
-import "strings"
-
 // DefaultRegistry creates a registry with all default linters
 func DefaultRegistry() *Registry {
 	registry := NewRegistry()
@@ -20,22 +18,20 @@ func DefaultRegistry() *Registry {
 	return registry
 }
 
-// ParseOutput parses the output of a linter
-func ParseOutput(result *Result) []string {
-	if result == nil || result.Output == "" {
-		return []string{}
+// New creates an unconfigured linter by name, e.g. to run it with options
+// other than those of the registered instance
+func New(name string) (Linter, bool) {
+	switch name {
+	case "phpstan":
+		return NewPHPStan(), true
+	case "phpcs":
+		return NewPHPCS(), true
+	case "php":
+		return NewPHP(), true
+	case "golangci-lint":
+		return NewGolangCI(), true
+	case "eslint":
+		return NewESLint(), true
 	}
-
-	// Split the output into lines
-	lines := strings.Split(result.Output, "\n")
-
-	// Remove empty lines
-	var filtered []string
-	for _, line := range lines {
-		if strings.TrimSpace(line) != "" {
-			filtered = append(filtered, line)
-		}
-	}
-
-	return filtered
+	return nil, false
 }
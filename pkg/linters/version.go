@@ -0,0 +1,244 @@
+package linters
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Version is a semantic version of a linter
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// String returns the version as "1.10.50"
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0 or 1 when v is older than, equal to or newer than o
+func (v Version) Compare(o Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		switch {
+		case d < 0:
+			return -1
+		case d > 0:
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionPattern matches the first version number in --version output, e.g.
+// "golangci-lint has version 1.55.2 built with go1.21.4" or "v8.57.0"
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// ParseVersion extracts the first version number from the given text
+func ParseVersion(text string) (Version, bool) {
+	m := versionPattern.FindStringSubmatch(text)
+	if m == nil {
+		return Version{}, false
+	}
+	return Version{Major: atoi(m[1]), Minor: atoi(m[2]), Patch: atoi(m[3])}, true
+}
+
+// Constraint is a set of version ranges that must all hold, such as
+// ">=1.10, <3", "^1.10", "~1.10.2" or "2.x"
+type Constraint struct {
+	text    string
+	clauses []clause
+}
+
+// clause is a single comparison of a constraint
+type clause struct {
+	op      string
+	version Version
+}
+
+var (
+	// clausePattern matches a single clause of a constraint
+	clausePattern = regexp.MustCompile(`^(\^|~|>=|<=|>|<|=)?v?(\d+)(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?$`)
+
+	// operatorSpacePattern matches operators followed by spaces
+	operatorSpacePattern = regexp.MustCompile(`(\^|~|>=|<=|>|<|=)\s+`)
+)
+
+// ParseConstraint parses a version constraint. Clauses are separated by
+// commas or spaces; operators may be followed by spaces.
+func ParseConstraint(text string) (*Constraint, error) {
+	c := &Constraint{text: strings.TrimSpace(text)}
+
+	// Join operators with their version, e.g. ">= 1.10" to ">=1.10"
+	normalized := operatorSpacePattern.ReplaceAllString(c.text, "$1")
+	fields := strings.FieldsFunc(normalized, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty version constraint")
+	}
+
+	for _, field := range fields {
+		m := clausePattern.FindStringSubmatch(field)
+		if m == nil {
+			return nil, fmt.Errorf("invalid version constraint %q: can't parse %q", text, field)
+		}
+		c.clauses = append(c.clauses, expand(m[1], m[2], m[3], m[4])...)
+	}
+	return c, nil
+}
+
+// expand turns a clause into plain comparisons. Missing or wildcard
+// components make a range, e.g. "1.2" is ">=1.2.0, <1.3.0".
+func expand(op, major, minor, patch string) []clause {
+	wild := func(s string) bool { return s == "" || s == "x" || s == "X" || s == "*" }
+	v := Version{Major: atoi(major), Minor: atoi(minor), Patch: atoi(patch)}
+
+	// next is the first version past the range the components describe
+	var next Version
+	switch {
+	case wild(minor):
+		next = Version{Major: v.Major + 1}
+	case wild(patch):
+		next = Version{Major: v.Major, Minor: v.Minor + 1}
+	default:
+		next = Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+
+	switch op {
+	case "^":
+		// Changes that don't modify the left-most non-zero component
+		switch {
+		case v.Major > 0 || wild(minor):
+			next = Version{Major: v.Major + 1}
+		case v.Minor > 0 || wild(patch):
+			next = Version{Minor: v.Minor + 1}
+		}
+		return []clause{{">=", v}, {"<", next}}
+	case "~":
+		if !wild(minor) {
+			next = Version{Major: v.Major, Minor: v.Minor + 1}
+		}
+		return []clause{{">=", v}, {"<", next}}
+	case ">", "<=":
+		// "<=1.2" includes every 1.2.x, ">1.2" none of them
+		if op == ">" {
+			return []clause{{">=", next}}
+		}
+		return []clause{{"<", next}}
+	case ">=", "<":
+		return []clause{{op, v}}
+	default:
+		return []clause{{">=", v}, {"<", next}}
+	}
+}
+
+// Check reports whether the version satisfies the constraint
+func (c *Constraint) Check(v Version) bool {
+	for _, cl := range c.clauses {
+		cmp := v.Compare(cl.version)
+		if (cl.op == ">=" && cmp < 0) || (cl.op == "<" && cmp >= 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the constraint as written in the config
+func (c *Constraint) String() string {
+	return c.text
+}
+
+// detectedVersion is a cached version of an executable
+type detectedVersion struct {
+	modTime time.Time
+	version Version
+}
+
+// versions caches the versions of executables by path
+var versions sync.Map
+
+// DetectVersion returns the version of the executable, running it with
+// --version in dir. Versions are cached until the executable changes.
+func DetectVersion(ctx context.Context, binary, dir string) (Version, error) {
+	// Commands found in the PATH are cached by their file too
+	path := binary
+	if found, err := exec.LookPath(binary); err == nil {
+		path = found
+	}
+	info, statErr := os.Stat(path)
+	if statErr == nil {
+		if cached, ok := versions.Load(path); ok && cached.(detectedVersion).modTime.Equal(info.ModTime()) {
+			return cached.(detectedVersion).version, nil
+		}
+	}
+
+	output, err := VersionOutput(ctx, binary, dir)
+	if err != nil {
+		return Version{}, err
+	}
+	version, ok := ParseVersion(output)
+	if !ok {
+		return Version{}, fmt.Errorf("no version number in %q", output)
+	}
+
+	if statErr == nil {
+		versions.Store(path, detectedVersion{modTime: info.ModTime(), version: version})
+	}
+	return version, nil
+}
+
+// versioned holds the version constraint of a linter, set with the
+// "version" option
+type versioned struct {
+	constraint    *Constraint
+	constraintErr error
+}
+
+// configureVersion reads the "version" option. A missing or empty option
+// removes the constraint, e.g. after a profile setting it is switched off.
+func (v *versioned) configureVersion(options map[string]interface{}) error {
+	v.constraint, v.constraintErr = nil, nil
+	text, _ := options["version"].(string)
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	v.constraint, v.constraintErr = ParseConstraint(text)
+	return v.constraintErr
+}
+
+// checkVersion returns the version of the executable of the linter and an
+// error when it doesn't satisfy the constraint. The version is only
+// detected when there is a constraint or when needed is true; a failing
+// detection is an error only with a constraint.
+func (v *versioned) checkVersion(ctx context.Context, name, binary, dir string, needed bool) (Version, error) {
+	if v.constraintErr != nil {
+		return Version{}, fmt.Errorf("%s: %w", name, v.constraintErr)
+	}
+	if v.constraint == nil && !needed {
+		return Version{}, nil
+	}
+
+	version, err := DetectVersion(ctx, binary, dir)
+	if err != nil {
+		if v.constraint != nil {
+			return Version{}, fmt.Errorf("%s: can't check the version constraint %q: %w", name, v.constraint, err)
+		}
+		return Version{}, nil
+	}
+	if err := CheckConstraint(v.constraint, version); err != nil {
+		return version, fmt.Errorf("%s %w", name, err)
+	}
+	return version, nil
+}
+
+// CheckConstraint returns an error when the version doesn't satisfy the
+// constraint of the config, if any
+func CheckConstraint(constraint *Constraint, version Version) error {
+	if constraint != nil && !constraint.Check(version) {
+		return fmt.Errorf("%s does not satisfy the version constraint %q of the config", version, constraint)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package linters
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		output string
+		want   Version
+	}{
+		{"PHPStan - PHP Static Analysis Tool 1.10.50", Version{1, 10, 50}},
+		{"golangci-lint has version 2.1.6 built with go1.24.3 from eabc2638", Version{2, 1, 6}},
+		{"v8.57.0", Version{8, 57, 0}},
+		{"PHP 8.3.6 (cli) (built: Apr 15 2024)", Version{8, 3, 6}},
+		{"PHP_CodeSniffer version 3.9", Version{3, 9, 0}},
+	}
+	for _, tc := range tests {
+		got, ok := ParseVersion(tc.output)
+		if !ok || got != tc.want {
+			t.Errorf("ParseVersion(%q) = %v, %t, want %v", tc.output, got, ok, tc.want)
+		}
+	}
+
+	if _, ok := ParseVersion("unknown"); ok {
+		t.Error("ParseVersion(unknown) succeeded, want no version")
+	}
+}
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    Version
+		want       bool
+	}{
+		{">=1.10", Version{1, 10, 0}, true},
+		{">=1.10", Version{1, 9, 9}, false},
+		{">=1.10, <3", Version{2, 5, 0}, true},
+		{">=1.10, <3", Version{3, 0, 0}, false},
+		{">= 1.10 < 3", Version{1, 11, 0}, true},
+		{"^2", Version{2, 9, 1}, true},
+		{"^2", Version{3, 0, 0}, false},
+		{"^0.4.1", Version{0, 4, 9}, true},
+		{"^0.4.1", Version{0, 5, 0}, false},
+		{"~1.10.2", Version{1, 10, 9}, true},
+		{"~1.10.2", Version{1, 11, 0}, false},
+		{"1.x", Version{1, 62, 2}, true},
+		{"1.x", Version{2, 0, 0}, false},
+		{"<=1.2", Version{1, 2, 9}, true},
+		{">1.2", Version{1, 2, 9}, false},
+		{"=2.1.6", Version{2, 1, 6}, true},
+		{"v2", Version{2, 1, 6}, true},
+	}
+	for _, tc := range tests {
+		c, err := ParseConstraint(tc.constraint)
+		if err != nil {
+			t.Errorf("ParseConstraint(%q) failed: %v", tc.constraint, err)
+			continue
+		}
+		if got := c.Check(tc.version); got != tc.want {
+			t.Errorf("%q.Check(%s) = %t, want %t", tc.constraint, tc.version, got, tc.want)
+		}
+	}
+
+	for _, text := range []string{"", "latest", ">=1.x.2.3", "=>1"} {
+		if _, err := ParseConstraint(text); err == nil {
+			t.Errorf("ParseConstraint(%q) succeeded, want an error", text)
+		}
+	}
+}
+
+func TestGolangciArgs(t *testing.T) {
+	args := []string{"run", "--out-format=colored-line-number", "--timeout=5m"}
+
+	if got := golangciArgs(args, Version{1, 64, 8}); !reflect.DeepEqual(got, args) {
+		t.Errorf("Version 1 args = %v, want %v", got, args)
+	}
+
+	want := []string{"run", "--output.text.path=stdout", "--output.text.colors=true", "--timeout=5m"}
+	if got := golangciArgs(args, Version{2, 1, 6}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Version 2 args = %v, want %v", got, want)
+	}
+
+	want = []string{"run", "--output.json.path=stdout"}
+	if got := golangciArgs([]string{"run", "--out-format=json", "--out-format=github-actions"}, Version{2, 0, 0}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Version 2 args = %v, want %v", got, want)
+	}
+}
+
+func TestConfigureVersion(t *testing.T) {
+	var v versioned
+	if err := v.configureVersion(map[string]interface{}{"version": "^2"}); err != nil || v.constraint == nil {
+		t.Fatalf("configureVersion(^2) = %v, constraint %v", err, v.constraint)
+	}
+
+	// Options without the key, e.g. after switching off a profile, remove it
+	if err := v.configureVersion(map[string]interface{}{"enabled": true}); err != nil || v.constraint != nil {
+		t.Errorf("configureVersion without version = %v, constraint %v, want none", err, v.constraint)
+	}
+
+	// So does a valid constraint after an invalid one
+	if err := v.configureVersion(map[string]interface{}{"version": "latest"}); err == nil {
+		t.Error("configureVersion(latest) succeeded, want an error")
+	}
+	if err := v.configureVersion(map[string]interface{}{}); err != nil || v.constraintErr != nil {
+		t.Errorf("configureVersion without version = %v, constraint error %v, want none", err, v.constraintErr)
+	}
+}
+
+func TestMajorVersionArgs(t *testing.T) {
+	t.Setenv("ESLINT_USE_FLAT_CONFIG", "")
+
+	tests := []struct {
+		name    string
+		argsFor func([]string, Version) []string
+		args    []string
+		version Version
+		want    []string
+	}{
+		{"phpstan 1", phpstanArgs, []string{"analyse", "--level=10"}, Version{1, 12, 0}, []string{"analyse", "--level=max"}},
+		{"phpstan 1 -l", phpstanArgs, []string{"analyse", "-l", "10"}, Version{1, 12, 0}, []string{"analyse", "-l", "max"}},
+		{"phpstan 2", phpstanArgs, []string{"analyse", "--level=10"}, Version{2, 1, 0}, []string{"analyse", "--level=10"}},
+		{"phpcs 3", phpcsArgs, []string{"--extensions=php,inc/php"}, Version{3, 9, 0}, []string{"--extensions=php,inc/php"}},
+		{"phpcs 4", phpcsArgs, []string{"--standard=PSR12", "--extensions=php,inc/php"}, Version{4, 0, 0}, []string{"--standard=PSR12", "--extensions=php,inc"}},
+		{"eslint 8", eslintArgs, []string{"--ext", ".js,.ts", "--no-eslintrc"}, Version{8, 57, 0}, []string{"--ext", ".js,.ts", "--no-eslintrc"}},
+		{"eslint 9.0", eslintArgs, []string{"--ext", ".js,.ts", "--rulesdir=rules", "--no-eslintrc", "--format=stylish"}, Version{9, 0, 0}, []string{"--no-config-lookup", "--format=stylish"}},
+		{"eslint 9.21", eslintArgs, []string{"--ext", ".js,.ts", "--ignore-path", ".gitignore"}, Version{9, 21, 0}, []string{"--ext", ".js,.ts"}},
+	}
+	for _, tc := range tests {
+		if got := tc.argsFor(tc.args, tc.version); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: args = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+
+	// ESLint 9 keeps the eslintrc options when told to use eslintrc
+	t.Setenv("ESLINT_USE_FLAT_CONFIG", "false")
+	args := []string{"--ext", ".js", "--no-eslintrc"}
+	if got := eslintArgs(args, Version{9, 0, 0}); !reflect.DeepEqual(got, args) {
+		t.Errorf("eslint 9 with eslintrc: args = %q, want %q", got, args)
+	}
+}
@@ -0,0 +1,242 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/crixuamg/pkg/config"
+)
+
+// snippetContext is the number of lines shown around each finding
+const snippetContext = 2
+
+// htmlFinding is the template view of a finding
+type htmlFinding struct {
+	Linter   string
+	File     string
+	Line     int
+	Column   int
+	Severity string
+	Message  string
+	Rule     string
+	Snippet  []SnippetLine
+}
+
+// htmlLinter is the template view of a linter section
+type htmlLinter struct {
+	LinterSummary
+	Anchor   string
+	Findings []htmlFinding
+}
+
+// htmlData is the data passed to the HTML template
+type htmlData struct {
+	Generated string
+	Root      string
+	Total     int
+	Errors    int
+	Warnings  int
+	Linters   []htmlLinter
+	Findings  []htmlFinding
+	Colors    config.ThemeColors
+}
+
+// WriteHTML renders the report as a self-contained HTML page styled with the given theme
+func (r *Report) WriteHTML(w io.Writer, theme config.ThemeConfig) error {
+	total, errors, warnings := r.Totals()
+	data := htmlData{
+		Generated: r.Generated.Format("2006-01-02 15:04:05"),
+		Root:      r.Root,
+		Total:     total,
+		Errors:    errors,
+		Warnings:  warnings,
+		Colors:    theme.Colors,
+	}
+
+	for _, l := range r.Linters {
+		section := htmlLinter{
+			LinterSummary: l,
+			Anchor:        "linter-" + strings.ReplaceAll(l.Name, " ", "-"),
+		}
+		for _, f := range l.Findings {
+			hf := htmlFinding{
+				Linter:   f.Linter,
+				File:     r.RelPath(f.File),
+				Line:     f.Line,
+				Column:   f.Column,
+				Severity: string(f.Severity),
+				Message:  f.Message,
+				Rule:     f.Rule,
+				Snippet:  r.Snippet(f.File, f.Line, snippetContext),
+			}
+			section.Findings = append(section.Findings, hf)
+			data.Findings = append(data.Findings, hf)
+		}
+		data.Linters = append(data.Linters, section)
+	}
+
+	if err := htmlTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return nil
+}
+
+// WriteHTMLFile writes the HTML report to path. When path is a directory
+// (or ends with a separator) the report is written to lazylint-report.html
+// inside it. It returns the path of the written file.
+func (r *Report) WriteHTMLFile(path string, theme config.ThemeConfig) (string, error) {
+	if info, err := os.Stat(path); (err == nil && info.IsDir()) || strings.HasSuffix(path, "/") || filepath.Ext(path) == "" {
+		path = filepath.Join(path, "lazylint-report.html")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer file.Close()
+
+	if err := r.WriteHTML(file, theme); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>LazyLint report</title>
+<style>
+:root {
+  --subtle: {{.Colors.Subtle}};
+  --highlight: {{.Colors.Highlight}};
+  --special: {{.Colors.Special}};
+  --error: {{.Colors.Error}};
+  --warning: {{.Colors.Warning}};
+  --border: {{.Colors.Border}};
+  --text: {{.Colors.Text}};
+  --dim: {{.Colors.DimText}};
+  --bg: {{.Colors.Background}};
+}
+body { background: var(--bg); color: var(--text); font-family: system-ui, sans-serif; margin: 2rem; }
+h1, h2 { color: var(--highlight); }
+a { color: var(--highlight); }
+.dim { color: var(--dim); }
+.cards { display: flex; gap: 1rem; flex-wrap: wrap; }
+.card { border: 1px solid var(--border); border-radius: 6px; padding: 0.75rem 1.25rem; min-width: 8rem; }
+.card .value { font-size: 1.6rem; font-weight: bold; }
+.error { color: var(--error); }
+.warning { color: var(--warning); }
+.ok { color: var(--special); }
+table { border-collapse: collapse; width: 100%; margin: 1rem 0; }
+th, td { border-bottom: 1px solid var(--subtle); padding: 0.35rem 0.5rem; text-align: left; vertical-align: top; }
+th { cursor: pointer; user-select: none; color: var(--highlight); }
+th.sorted-asc::after { content: " ▲"; }
+th.sorted-desc::after { content: " ▼"; }
+.filters { display: flex; gap: 0.5rem; flex-wrap: wrap; }
+input, select { background: var(--bg); color: var(--text); border: 1px solid var(--border); border-radius: 4px; padding: 0.3rem 0.5rem; }
+pre { margin: 0.3rem 0 0; padding: 0.4rem; border: 1px solid var(--subtle); border-radius: 4px; overflow-x: auto; }
+pre .target { color: var(--error); font-weight: bold; }
+pre .num { color: var(--dim); display: inline-block; min-width: 3em; }
+details > summary { cursor: pointer; }
+</style>
+</head>
+<body>
+<h1>LazyLint report</h1>
+<p class="dim">Generated {{.Generated}}{{if .Root}} for {{.Root}}{{end}}</p>
+
+<h2>Summary</h2>
+<div class="cards">
+  <div class="card"><div class="value">{{.Total}}</div>findings</div>
+  <div class="card"><div class="value error">{{.Errors}}</div>errors</div>
+  <div class="card"><div class="value warning">{{.Warnings}}</div>warnings</div>
+</div>
+<table>
+  <thead><tr><th>Linter</th><th>Status</th><th>Errors</th><th>Warnings</th><th>Duration</th></tr></thead>
+  <tbody>
+  {{range .Linters}}<tr>
+    <td><a href="#{{.Anchor}}">{{.Name}}</a></td>
+    <td>{{if .Success}}<span class="ok">passed</span>{{else}}<span class="error">failed</span>{{end}}</td>
+    <td>{{.Errors}}</td>
+    <td>{{.Warnings}}</td>
+    <td>{{.Duration}}</td>
+  </tr>{{end}}
+  </tbody>
+</table>
+
+<h2>Findings</h2>
+<div class="filters">
+  <input id="filter-text" type="search" placeholder="Filter by file or message">
+  <select id="filter-linter"><option value="">All linters</option>{{range .Linters}}<option>{{.Name}}</option>{{end}}</select>
+  <select id="filter-severity"><option value="">All severities</option><option>error</option><option>warning</option><option>info</option></select>
+</div>
+<table id="findings">
+  <thead><tr><th data-type="text">Linter</th><th data-type="text">Severity</th><th data-type="text">File</th><th data-type="number">Line</th><th data-type="text">Message</th></tr></thead>
+  <tbody>
+  {{range .Findings}}<tr data-linter="{{.Linter}}" data-severity="{{.Severity}}">
+    <td>{{.Linter}}</td>
+    <td class="{{.Severity}}">{{.Severity}}</td>
+    <td>{{.File}}</td>
+    <td>{{.Line}}</td>
+    <td>{{.Message}}{{if .Rule}} <span class="dim">({{.Rule}})</span>{{end}}
+      {{if .Snippet}}<details><summary class="dim">code</summary><pre>{{range .Snippet}}<span{{if .Target}} class="target"{{end}}><span class="num">{{.Number}}</span>{{.Text}}</span>
+{{end}}</pre></details>{{end}}
+    </td>
+  </tr>{{end}}
+  </tbody>
+</table>
+
+{{range .Linters}}
+<h2 id="{{.Anchor}}">{{.Name}}</h2>
+<p>{{if .Success}}<span class="ok">passed</span>{{else}}<span class="error">failed</span>{{end}} in {{.Duration}} &middot; {{.Errors}} errors, {{.Warnings}} warnings</p>
+{{if .Findings}}<ul>{{range .Findings}}<li><span class="{{.Severity}}">{{.Severity}}</span> {{.File}}:{{.Line}} {{.Message}}</li>{{end}}</ul>{{end}}
+<details><summary class="dim">raw output</summary><pre>{{.Output}}{{if .Stderr}}
+{{.Stderr}}{{end}}</pre></details>
+{{end}}
+
+<script>
+(function () {
+  var table = document.getElementById("findings");
+  var rows = Array.prototype.slice.call(table.tBodies[0].rows);
+  var text = document.getElementById("filter-text");
+  var linter = document.getElementById("filter-linter");
+  var severity = document.getElementById("filter-severity");
+
+  function applyFilters() {
+    var q = text.value.toLowerCase();
+    rows.forEach(function (row) {
+      var visible = (!q || row.textContent.toLowerCase().indexOf(q) !== -1) &&
+        (!linter.value || row.dataset.linter === linter.value) &&
+        (!severity.value || row.dataset.severity === severity.value);
+      row.style.display = visible ? "" : "none";
+    });
+  }
+  [text, linter, severity].forEach(function (el) { el.addEventListener("input", applyFilters); });
+
+  Array.prototype.forEach.call(table.tHead.rows[0].cells, function (th, index) {
+    th.addEventListener("click", function () {
+      var asc = !th.classList.contains("sorted-asc");
+      Array.prototype.forEach.call(table.tHead.rows[0].cells, function (c) { c.classList.remove("sorted-asc", "sorted-desc"); });
+      th.classList.add(asc ? "sorted-asc" : "sorted-desc");
+      rows.sort(function (a, b) {
+        var x = a.cells[index].textContent.trim(), y = b.cells[index].textContent.trim();
+        var cmp = th.dataset.type === "number" ? (parseFloat(x) || 0) - (parseFloat(y) || 0) : x.localeCompare(y);
+        return asc ? cmp : -cmp;
+      });
+      rows.forEach(function (row) { table.tBodies[0].appendChild(row); });
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,213 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crixuamg/pkg/linters"
+)
+
+// DefaultLinkTemplate links findings relative to the markdown document
+const DefaultLinkTemplate = "{path}#L{line}"
+
+// MarkdownOptions controls the markdown output
+type MarkdownOptions struct {
+	// LinkTemplate builds the link of a finding. The placeholders {path},
+	// {line} and {commit} are replaced by the finding's values.
+	LinkTemplate string
+
+	// Commit is substituted for {commit} in LinkTemplate
+	Commit string
+
+	// Baseline holds the findings of a previous run. When set, a section
+	// listing new and fixed findings is added.
+	Baseline []linters.Finding
+}
+
+// link renders the link to a finding using the configured template
+func (o MarkdownOptions) link(path string, line int) string {
+	template := o.LinkTemplate
+	if template == "" {
+		template = DefaultLinkTemplate
+	}
+	return strings.NewReplacer(
+		"{path}", path,
+		"{line}", strconv.Itoa(line),
+		"{commit}", o.Commit,
+	).Replace(template)
+}
+
+// WriteMarkdown renders the report as markdown suitable for merge request descriptions
+func (r *Report) WriteMarkdown(w io.Writer, opts MarkdownOptions) error {
+	var b strings.Builder
+	total, errors, warnings := r.Totals()
+
+	b.WriteString("## LazyLint results\n\n")
+	if total == 0 {
+		b.WriteString("✅ No findings.\n\n")
+	} else {
+		b.WriteString(fmt.Sprintf("**%d findings**: %d errors, %d warnings\n\n", total, errors, warnings))
+	}
+
+	// Totals per linter
+	b.WriteString("| Linter | Status | Errors | Warnings | Duration |\n")
+	b.WriteString("|--------|--------|-------:|---------:|---------:|\n")
+	for _, l := range r.Linters {
+		status := "✅ passed"
+		if !l.Success {
+			status = "❌ failed"
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %s |\n", escapeMarkdown(l.Name), status, l.Errors, l.Warnings, l.Duration.Round(10*time.Millisecond)))
+	}
+	b.WriteString("\n")
+
+	// New vs baseline
+	if opts.Baseline != nil {
+		current := make([]linters.Finding, 0, len(r.Findings))
+		for _, f := range r.Findings {
+			f.File = r.RelPath(f.File)
+			current = append(current, f)
+		}
+
+		added, fixed := Compare(current, opts.Baseline)
+		b.WriteString("### New vs baseline\n\n")
+		b.WriteString(fmt.Sprintf("%d new, %d fixed\n\n", len(added), len(fixed)))
+		if len(added) > 0 {
+			b.WriteString("**New**\n\n")
+			for _, f := range added {
+				b.WriteString(r.markdownFinding(f, opts, true))
+			}
+			b.WriteString("\n")
+		}
+		if len(fixed) > 0 {
+			b.WriteString("**Fixed**\n\n")
+			for _, f := range fixed {
+				b.WriteString(r.markdownFinding(f, opts, true))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	// Findings per file
+	var (
+		files  []string
+		byFile = make(map[string][]linters.Finding)
+	)
+	for _, f := range r.Findings {
+		path := r.RelPath(f.File)
+		if _, ok := byFile[path]; !ok {
+			files = append(files, path)
+		}
+		byFile[path] = append(byFile[path], f)
+	}
+
+	for _, path := range files {
+		findings := byFile[path]
+		b.WriteString(fmt.Sprintf("<details>\n<summary><code>%s</code> (%d)</summary>\n\n", html.EscapeString(path), len(findings)))
+		for _, f := range findings {
+			b.WriteString(r.markdownFinding(f, opts, false))
+		}
+		b.WriteString("\n</details>\n\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownFinding renders a single finding as a list item
+func (r *Report) markdownFinding(f linters.Finding, opts MarkdownOptions, withPath bool) string {
+	path := r.RelPath(f.File)
+	icon := "🔴"
+	if f.Severity == linters.SeverityWarning {
+		icon = "🟡"
+	}
+
+	label := fmt.Sprintf("L%d", f.Line)
+	if withPath {
+		label = fmt.Sprintf("%s:%d", escapeMarkdown(path), f.Line)
+	}
+
+	message := escapeMarkdown(f.Message)
+	if f.Rule != "" {
+		message += fmt.Sprintf(" (`%s`)", f.Rule)
+	}
+
+	return fmt.Sprintf("- %s [%s](%s) **%s**: %s\n", icon, label, opts.link(path, f.Line), f.Linter, message)
+}
+
+// escapeMarkdown escapes text for use in list items and table cells, so
+// messages like "expected <string>" don't turn into HTML tags
+func escapeMarkdown(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "|", "\\|")
+}
+
+// findingKey identifies a finding independently of its line number, so
+// findings survive unrelated edits above them
+func findingKey(f linters.Finding) string {
+	return f.Linter + "\x00" + f.File + "\x00" + f.Message
+}
+
+// Compare returns the findings that are new in current and the ones from
+// baseline that no longer occur
+func Compare(current, baseline []linters.Finding) (added, fixed []linters.Finding) {
+	remaining := make(map[string]int)
+	for _, f := range baseline {
+		remaining[findingKey(f)]++
+	}
+	for _, f := range current {
+		key := findingKey(f)
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		added = append(added, f)
+	}
+
+	present := make(map[string]int)
+	for _, f := range current {
+		present[findingKey(f)]++
+	}
+	for _, f := range baseline {
+		key := findingKey(f)
+		if present[key] > 0 {
+			present[key]--
+			continue
+		}
+		fixed = append(fixed, f)
+	}
+
+	return added, fixed
+}
+
+// WriteJSON writes the findings of the report as JSON, usable as a baseline
+func (r *Report) WriteJSON(w io.Writer) error {
+	findings := make([]linters.Finding, 0, len(r.Findings))
+	for _, f := range r.Findings {
+		f.File = r.RelPath(f.File)
+		findings = append(findings, f)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(findings)
+}
+
+// LoadBaseline reads findings previously written by WriteJSON
+func LoadBaseline(path string) ([]linters.Finding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	findings := []linters.Finding{}
+	if err := json.Unmarshal(data, &findings); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline: %w", err)
+	}
+	return findings, nil
+}
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/crixuamg/pkg/linters"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	results := []*linters.Result{
+		{Name: "phpstan", Output: "src/Foo.php:12:Call to undefined method\nsrc/Foo.php:20:Unused variable\n"},
+	}
+	rep := New(results, "")
+
+	var buf strings.Builder
+	opts := MarkdownOptions{
+		LinkTemplate: "https://example.com/blob/{commit}/{path}#L{line}",
+		Commit:       "abc123",
+		Baseline: []linters.Finding{
+			{Linter: "phpstan", File: "src/Foo.php", Line: 10, Message: "Call to undefined method"},
+			{Linter: "phpstan", File: "src/Bar.php", Line: 3, Message: "Fixed meanwhile"},
+		},
+	}
+	if err := rep.WriteMarkdown(&buf, opts); err != nil {
+		t.Fatalf("WriteMarkdown failed: %v", err)
+	}
+
+	md := buf.String()
+	expected := []string{
+		"| phpstan | ❌ failed | 2 | 0 |",
+		"<details>\n<summary><code>src/Foo.php</code> (2)</summary>",
+		"(https://example.com/blob/abc123/src/Foo.php#L12)",
+		"### New vs baseline",
+		"1 new, 1 fixed",
+		"[src/Foo.php:20](https://example.com/blob/abc123/src/Foo.php#L20)",
+		"**Fixed**\n\n- 🔴 [src/Bar.php:3](https://example.com/blob/abc123/src/Bar.php#L3) **phpstan**: Fixed meanwhile",
+	}
+	for _, want := range expected {
+		if !strings.Contains(md, want) {
+			t.Errorf("Expected markdown to contain %q, got:\n%s", want, md)
+		}
+	}
+}
+
+func TestWriteMarkdownEscapes(t *testing.T) {
+	results := []*linters.Result{
+		{Name: "phpstan", Output: "src/<Foo>.php:12:Expected array<int|string>, got null\n"},
+	}
+	rep := New(results, "")
+
+	var buf strings.Builder
+	if err := rep.WriteMarkdown(&buf, MarkdownOptions{}); err != nil {
+		t.Fatalf("WriteMarkdown failed: %v", err)
+	}
+
+	md := buf.String()
+	for _, want := range []string{
+		"<summary><code>src/&lt;Foo&gt;.php</code> (1)</summary>",
+		"Expected array&lt;int\\|string&gt;, got null",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Expected markdown to contain %q, got:\n%s", want, md)
+		}
+	}
+}
+
+func TestDefaultLinkTemplate(t *testing.T) {
+	if link := (MarkdownOptions{}).link("src/Foo.php", 7); link != "src/Foo.php#L7" {
+		t.Errorf("Unexpected default link: %s", link)
+	}
+}
@@ -0,0 +1,144 @@
+package report
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/crixuamg/pkg/linters"
+)
+
+// LinterSummary holds the outcome of a single linter in a report
+type LinterSummary struct {
+	Name     string
+	Success  bool
+	Duration time.Duration
+	Findings []linters.Finding
+	Errors   int
+	Warnings int
+	Output   string
+	Stderr   string
+}
+
+// Report aggregates the results of a lint run
+type Report struct {
+	Generated time.Time
+	Root      string
+	Linters   []LinterSummary
+	Findings  []linters.Finding
+}
+
+// New aggregates the given linter results into a report. Relative finding
+// paths are resolved against root when reading code snippets.
+func New(results []*linters.Result, root string) *Report {
+	r := &Report{
+		Generated: time.Now(),
+		Root:      root,
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		findings := linters.ParseFindings(result)
+		errors, warnings := linters.CountBySeverity(findings)
+		r.Linters = append(r.Linters, LinterSummary{
+			Name:     result.Label(),
+			Success:  result.Success,
+			Duration: result.Duration,
+			Findings: findings,
+			Errors:   errors,
+			Warnings: warnings,
+			Output:   linters.StripANSI(result.Output),
+			Stderr:   linters.StripANSI(result.Error),
+		})
+		r.Findings = append(r.Findings, findings...)
+	}
+
+	sort.Slice(r.Linters, func(i, j int) bool {
+		return r.Linters[i].Name < r.Linters[j].Name
+	})
+	sort.SliceStable(r.Findings, func(i, j int) bool {
+		if r.Findings[i].File != r.Findings[j].File {
+			return r.Findings[i].File < r.Findings[j].File
+		}
+		return r.Findings[i].Line < r.Findings[j].Line
+	})
+
+	return r
+}
+
+// Totals returns the number of findings, errors and warnings in the report
+func (r *Report) Totals() (findings, errors, warnings int) {
+	for _, l := range r.Linters {
+		findings += len(l.Findings)
+		errors += l.Errors
+		warnings += l.Warnings
+	}
+	return findings, errors, warnings
+}
+
+// RelPath returns the path of a finding relative to the report root when possible
+func (r *Report) RelPath(path string) string {
+	if r.Root == "" || !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(r.Root, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// SnippetLine is a single line of source code around a finding
+type SnippetLine struct {
+	Number int
+	Text   string
+	Target bool
+}
+
+// Snippet reads the lines surrounding the given line of a file. It returns
+// nil when the file can't be read.
+func (r *Report) Snippet(path string, line, context int) []SnippetLine {
+	if line <= 0 {
+		return nil
+	}
+
+	file, err := os.Open(r.resolve(path))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var snippet []SnippetLine
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		if n < line-context {
+			continue
+		}
+		if n > line+context {
+			break
+		}
+		snippet = append(snippet, SnippetLine{
+			Number: n,
+			Text:   scanner.Text(),
+			Target: n == line,
+		})
+	}
+	return snippet
+}
+
+// resolve returns a readable location for the given finding path
+func (r *Report) resolve(path string) string {
+	if filepath.IsAbs(path) || r.Root == "" {
+		return path
+	}
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	return filepath.Join(r.Root, path)
+}
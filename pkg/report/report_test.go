@@ -0,0 +1,79 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/linters"
+)
+
+func TestNewAggregatesFindings(t *testing.T) {
+	results := []*linters.Result{
+		{Name: "phpcs", Output: "FILE: src/Foo.php\n  4 | ERROR   | Missing brace\n 10 | WARNING | Line too long\n"},
+		{Name: "golangci-lint", Output: "main.go:3:1: unused variable (unused)\n", Success: false},
+	}
+
+	rep := New(results, "")
+	if len(rep.Linters) != 2 {
+		t.Fatalf("Expected 2 linters, got %d", len(rep.Linters))
+	}
+	if rep.Linters[0].Name != "golangci-lint" {
+		t.Errorf("Expected linters sorted by name, got %s first", rep.Linters[0].Name)
+	}
+
+	total, errors, warnings := rep.Totals()
+	if total != 3 || errors != 2 || warnings != 1 {
+		t.Errorf("Expected 3 findings (2 errors, 1 warning), got %d (%d, %d)", total, errors, warnings)
+	}
+	if rep.Findings[0].File != "main.go" {
+		t.Errorf("Expected findings sorted by file, got %s first", rep.Findings[0].File)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	root := t.TempDir()
+	source := "line1\nline2\nline3\nline4\nline5\nline6\n"
+	if err := os.WriteFile(filepath.Join(root, "file.php"), []byte(source), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	rep := &Report{Root: root}
+	snippet := rep.Snippet("file.php", 3, 1)
+	if len(snippet) != 3 {
+		t.Fatalf("Expected 3 lines, got %d", len(snippet))
+	}
+	if snippet[0].Number != 2 || !snippet[1].Target || snippet[2].Text != "line4" {
+		t.Errorf("Unexpected snippet: %+v", snippet)
+	}
+
+	if rep.Snippet("missing.php", 3, 1) != nil {
+		t.Error("Expected no snippet for a missing file")
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	results := []*linters.Result{
+		{Name: "eslint", Output: "/app/index.js\n  1:1  error  <script> is not allowed  no-html\n"},
+	}
+
+	var buf bytes.Buffer
+	theme := config.DefaultConfig().UI.Themes["tokyo-night"]
+	if err := New(results, "").WriteHTML(&buf, theme); err != nil {
+		t.Fatalf("WriteHTML failed: %v", err)
+	}
+
+	html := buf.String()
+	if !strings.Contains(html, theme.Colors.Background) {
+		t.Error("Expected theme colors in the report")
+	}
+	if strings.Contains(html, "<script> is not allowed") {
+		t.Error("Expected finding messages to be escaped")
+	}
+	if strings.Contains(html, "<link") || strings.Contains(html, "src=\"http") {
+		t.Error("Expected a self-contained report without external assets")
+	}
+}
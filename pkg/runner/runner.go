@@ -0,0 +1,234 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/linters"
+	"github.com/crixuamg/pkg/workspace"
+)
+
+// Job is a single invocation of a linter
+type Job struct {
+	Linter  linters.Linter
+	Targets []string
+
+	// Partition and Dir are set for the targets of an override, see
+	// linters.Result
+	Partition string
+	Dir       string
+}
+
+// Run invokes the linter of the job on its targets
+func (j Job) Run(ctx context.Context) (*linters.Result, error) {
+	if j.Dir != "" {
+		ctx = linters.WithWorkDir(ctx, j.Dir)
+	}
+
+	result, err := j.Linter.Run(ctx, j.Targets...)
+	if result != nil {
+		result.Partition = j.Partition
+		result.Dir = j.Dir
+	}
+	return result, err
+}
+
+// Plan splits a run of the given linters into jobs. Without targets every
+// linter runs on its default scope, once or from each of its projects, see
+// workspace.Workspace.Roots. Otherwise each linter gets the
+// targets it can process, partitioned by the override of cfg that applies
+// to them and by the sub-project of ws that owns them, so that each
+// partition runs with its own options from its own directory. Override
+// paths and project directories are relative to root. Linters that can't
+// process any of the targets are left out.
+func Plan(cfg *config.Config, ws *workspace.Workspace, root string, toRun []linters.Linter, targets []string) []Job {
+	var jobs []Job
+	for _, linter := range toRun {
+		if len(targets) == 0 {
+			roots := ws.Roots(linter.Name())
+			if len(roots) == 0 {
+				jobs = append(jobs, Job{Linter: linter})
+			}
+			for _, project := range roots {
+				jobs = append(jobs, Job{
+					Linter:    linter,
+					Partition: project.Dir,
+					Dir:       filepath.Join(root, filepath.FromSlash(project.Dir)),
+				})
+			}
+			continue
+		}
+
+		linterTargets := linters.FilterTargets(linter, targets)
+		if len(linterTargets) == 0 {
+			continue
+		}
+
+		// Group the targets by the first override that matches them and by
+		// their project; files of the root project run as before
+		var (
+			plain      []string
+			keys       []partition
+			partitions = make(map[partition][]string)
+		)
+		for _, target := range linterTargets {
+			key := partitionOf(cfg, ws, root, linter.Name(), target)
+			if key.override < 0 && key.project == "" {
+				plain = append(plain, target)
+				continue
+			}
+
+			// Partitions run in their own directory, so their targets are absolute
+			if abs, err := filepath.Abs(target); err == nil {
+				target = abs
+			}
+			if _, ok := partitions[key]; !ok {
+				keys = append(keys, key)
+			}
+			partitions[key] = append(partitions[key], target)
+		}
+
+		if len(plain) > 0 {
+			jobs = append(jobs, Job{Linter: linter, Targets: plain})
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].override != keys[j].override {
+				return keys[i].override < keys[j].override
+			}
+			return keys[i].project < keys[j].project
+		})
+		for _, key := range keys {
+			if job, ok := partitionJob(cfg, root, linter, key, partitions[key]); ok {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+	return jobs
+}
+
+// partition identifies the targets of a linter sharing an override and a
+// project
+type partition struct {
+	// override is the index of the override, -1 for none
+	override int
+
+	// project is the directory of the project, empty for the root project
+	project string
+}
+
+// partitionOf returns the partition of the target for the linter
+func partitionOf(cfg *config.Config, ws *workspace.Workspace, root, name, target string) partition {
+	key := partition{override: -1}
+
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return key
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return key
+	}
+	rel = filepath.ToSlash(rel)
+
+	if cfg != nil {
+		key.override = cfg.OverrideFor(name, rel)
+	}
+	if project := ws.Owner(name, rel); project != nil && project.Dir != "." {
+		key.project = project.Dir
+	}
+	return key
+}
+
+// partitionJob creates the job running the linter on the targets of a
+// partition. The job is skipped when the override disables the linter.
+func partitionJob(cfg *config.Config, root string, linter linters.Linter, key partition, targets []string) (Job, bool) {
+	job := Job{Linter: linter, Targets: targets}
+
+	// Projects run from their directory
+	if key.project != "" {
+		job.Partition = key.project
+		job.Dir = filepath.Join(root, filepath.FromSlash(key.project))
+	}
+
+	if key.override >= 0 {
+		options := cfg.OverrideOptions(linter.Name(), key.override)
+		if enabled, ok := options["enabled"].(bool); ok && !enabled {
+			return Job{}, false
+		}
+
+		// Configure a new instance to leave the options of the linter untouched
+		if instance, ok := linters.New(linter.Name()); ok {
+			instance.Configure(options)
+			job.Linter = instance
+		}
+
+		override := cfg.Overrides[key.override]
+		paths := strings.Join(override.Paths, ", ")
+		if key.project == "" {
+			job.Partition = paths
+			job.Dir = filepath.Join(root, override.WorkDir())
+		} else {
+			job.Partition = paths + " in " + key.project
+		}
+
+		// An explicit directory wins over the one of the project
+		if override.Dir != "" {
+			job.Dir = filepath.Join(root, override.WorkDir())
+		}
+	}
+
+	return job, true
+}
+
+// Run executes the given linters concurrently on the targets and returns
+// their results sorted by linter name. Linters that can't process any of
+// the targets are skipped. Without targets every linter runs on its
+// default scope.
+func Run(ctx context.Context, toRun []linters.Linter, targets []string) ([]*linters.Result, error) {
+	return RunJobs(ctx, Plan(nil, nil, "", toRun, targets))
+}
+
+// RunJobs executes the given jobs concurrently and returns their results
+// sorted by linter name and partition
+func RunJobs(ctx context.Context, jobs []Job) ([]*linters.Result, error) {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results []*linters.Result
+		errs    []error
+	)
+
+	for _, job := range jobs {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+
+			result, err := job.Run(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", job.Linter.Name(), err))
+			}
+			if result != nil {
+				results = append(results, result)
+			}
+		}(job)
+	}
+
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Label() < results[j].Label()
+	})
+
+	if len(errs) > 0 {
+		return results, fmt.Errorf("%d linter(s) failed: %v", len(errs), errs)
+	}
+	return results, nil
+}
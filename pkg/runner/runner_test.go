@@ -0,0 +1,131 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/linters"
+	"github.com/crixuamg/pkg/workspace"
+)
+
+// fakeLinter records the targets and working directory of its runs
+type fakeLinter struct {
+	name       string
+	extensions []string
+}
+
+func (l *fakeLinter) Name() string                           { return l.name }
+func (l *fakeLinter) Description() string                    { return "fake" }
+func (l *fakeLinter) IsAvailable() bool                      { return true }
+func (l *fakeLinter) Configure(map[string]interface{}) error { return nil }
+
+func (l *fakeLinter) FileExtensions() []string {
+	if l.extensions == nil {
+		return []string{".php"}
+	}
+	return l.extensions
+}
+
+func (l *fakeLinter) Run(ctx context.Context, targets ...string) (*linters.Result, error) {
+	return &linters.Result{Name: l.name, Success: true, Output: linters.WorkDir(ctx)}, nil
+}
+
+func TestPlan(t *testing.T) {
+	root := t.TempDir()
+	files := []string{
+		filepath.Join(root, "services", "legacy", "User.php"),
+		filepath.Join(root, "services", "new", "User.php"),
+		filepath.Join(root, "tools", "build.php"),
+		filepath.Join(root, "frontend", "app.js"),
+	}
+	for _, file := range files {
+		os.MkdirAll(filepath.Dir(file), 0755)
+		os.WriteFile(file, nil, 0644)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Overrides = []config.Override{
+		{Paths: []string{"services/legacy/**"}, Linters: map[string]map[string]interface{}{
+			"phpstan": {"args": []string{"analyse", "--level=3"}},
+		}},
+		{Paths: []string{"services/new/**"}, Linters: map[string]map[string]interface{}{
+			"phpstan": {"enabled": false},
+		}},
+	}
+
+	phpstan := &fakeLinter{name: "phpstan"}
+	jobs := Plan(cfg, nil, root, []linters.Linter{phpstan}, files)
+
+	// The new service disables PHPStan, the JS file isn't a target
+	if len(jobs) != 2 {
+		t.Fatalf("Got %d jobs, want 2: %+v", len(jobs), jobs)
+	}
+	if jobs[0].Linter != phpstan || !reflect.DeepEqual(jobs[0].Targets, []string{files[2]}) || jobs[0].Dir != "" {
+		t.Errorf("First job = %+v, want the configured linter on tools/build.php", jobs[0])
+	}
+
+	legacy := jobs[1]
+	if legacy.Linter == phpstan || legacy.Partition != "services/legacy/**" {
+		t.Errorf("Second job = %+v, want a new instance for services/legacy/**", legacy)
+	}
+	if want := filepath.Join(root, "services", "legacy"); legacy.Dir != want {
+		t.Errorf("Dir = %q, want %q", legacy.Dir, want)
+	}
+	if !reflect.DeepEqual(legacy.Targets, []string{files[0]}) {
+		t.Errorf("Targets = %v, want %v", legacy.Targets, files[:1])
+	}
+}
+
+func TestRunJobs(t *testing.T) {
+	dir := t.TempDir()
+	jobs := []Job{
+		{Linter: &fakeLinter{name: "phpstan"}, Targets: []string{"a.php"}, Partition: "services/**", Dir: dir},
+		{Linter: &fakeLinter{name: "phpstan"}, Targets: []string{"b.php"}},
+	}
+
+	results, err := RunJobs(context.Background(), jobs)
+	if err != nil {
+		t.Fatalf("RunJobs failed: %v", err)
+	}
+	if len(results) != 2 || results[0].Label() != "phpstan" || results[1].Label() != "phpstan (services/**)" {
+		t.Fatalf("Got results %+v, want phpstan and its partition", results)
+	}
+	if results[1].Output != dir || results[1].Dir != dir {
+		t.Errorf("Partition ran in %q with Dir %q, want %q", results[1].Output, results[1].Dir, dir)
+	}
+}
+
+func TestPlanProjects(t *testing.T) {
+	root := t.TempDir()
+	files := []string{
+		filepath.Join(root, "services", "api", "main.go"),
+		filepath.Join(root, "services", "worker", "main.go"),
+	}
+	ws := &workspace.Workspace{Root: root, Projects: []workspace.Project{
+		{Dir: "services/api", Files: []string{"go.mod"}},
+		{Dir: "services/worker", Files: []string{"go.mod"}},
+	}}
+	golangci := &fakeLinter{name: "golangci-lint", extensions: []string{".go"}}
+
+	// Each Go module is linted from its directory
+	jobs := Plan(nil, ws, root, []linters.Linter{golangci}, files)
+	if len(jobs) != 2 {
+		t.Fatalf("Got %d jobs, want 2: %+v", len(jobs), jobs)
+	}
+	for i, project := range []string{"services/api", "services/worker"} {
+		want := filepath.Join(root, filepath.FromSlash(project))
+		if jobs[i].Partition != project || jobs[i].Dir != want || !reflect.DeepEqual(jobs[i].Targets, files[i:i+1]) {
+			t.Errorf("Job %d = %+v, want %s from %s", i, jobs[i], files[i], want)
+		}
+	}
+
+	// So is the whole repository
+	jobs = Plan(nil, ws, root, []linters.Linter{golangci}, nil)
+	if len(jobs) != 2 || jobs[0].Dir != filepath.Join(root, "services", "api") || len(jobs[0].Targets) != 0 {
+		t.Errorf("Jobs without targets = %+v, want one per module", jobs)
+	}
+}
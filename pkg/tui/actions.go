@@ -0,0 +1,555 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/git"
+	"github.com/crixuamg/pkg/linters"
+)
+
+// anyTab scopes an action to all tabs
+const anyTab = -1
+
+// Action is a command that can be triggered by its keys or from the command
+// palette. All keybindings, the help bar and the palette derive from the
+// registered actions.
+type Action struct {
+	// ID identifies the action, e.g. "explorer_run"
+	ID string
+
+	// Title describes the action in the palette
+	Title string
+
+	// Help is the short description in the help bar, empty to leave it out
+	Help string
+
+	// Keys trigger the action, as reported by tea.KeyMsg.String()
+	Keys []string
+
+	// Tab is the tab whose keys trigger the action, or anyTab
+	Tab int
+
+	// Layout limits the action to config.LayoutTabs or config.LayoutPanes,
+	// empty for both
+	Layout string
+
+	// Run performs the action
+	Run func(m *Model) tea.Cmd
+}
+
+// KeyHelp renders the keys of the action for help texts, e.g. "a" or ":/ctrl+k"
+func (a Action) KeyHelp() string {
+	names := make([]string, len(a.Keys))
+	for i, k := range a.Keys {
+		names[i] = keyName(k)
+	}
+	return strings.Join(names, "/")
+}
+
+// keyName returns the display name of a key
+func keyName(k string) string {
+	if k == " " {
+		return "space"
+	}
+	return k
+}
+
+// defaultActions builds the action registry for the given linters and themes
+func defaultActions(available []linters.Linter, themes []string) []Action {
+	actions := []Action{
+		// Global actions
+		{ID: "quit", Title: "Quit", Help: "quit", Keys: []string{"q", "ctrl+c"}, Tab: anyTab,
+			Run: func(m *Model) tea.Cmd { return tea.Quit }},
+		{ID: "help", Title: "Toggle full help", Help: "help", Keys: []string{"?"}, Tab: anyTab,
+			Run: func(m *Model) tea.Cmd {
+				m.help.ShowAll = !m.help.ShowAll
+				m.resize()
+				return nil
+			}},
+		{ID: "command_palette", Title: "Open command palette", Help: "commands", Keys: []string{":", "ctrl+k"}, Tab: anyTab,
+			Run: func(m *Model) tea.Cmd { return m.palette.Open(m.layoutActions()) }},
+		{ID: "find_files", Title: "Find files", Help: "find files", Keys: []string{"ctrl+p"}, Tab: anyTab,
+			Run: func(m *Model) tea.Cmd {
+				return m.finder.Open(m.explorer.rootDir, m.explorer.lintableFiles(m.explorer.rootDir))
+			}},
+		{ID: "cycle_theme", Title: "Cycle through themes", Help: "theme", Keys: []string{"t"}, Tab: anyTab,
+			Run: func(m *Model) tea.Cmd {
+				m.cycleTheme()
+				return nil
+			}},
+		{ID: "cycle_profile", Title: "Cycle through profiles", Help: "profile", Keys: []string{"P"}, Tab: anyTab,
+			Run: func(m *Model) tea.Cmd {
+				m.cycleProfile()
+				return nil
+			}},
+		{ID: "next_tab", Title: "Next tab or pane", Help: "next tab", Keys: []string{"tab"}, Tab: anyTab,
+			Run: func(m *Model) tea.Cmd {
+				if m.panesLayout() {
+					return m.cyclePanes(1)
+				}
+				m.activeTab = (m.activeTab + 1) % len(tabNames)
+				return nil
+			}},
+		{ID: "previous_tab", Title: "Previous tab or pane", Keys: []string{"shift+tab"}, Tab: anyTab,
+			Run: func(m *Model) tea.Cmd {
+				if m.panesLayout() {
+					return m.cyclePanes(-1)
+				}
+				m.activeTab = (m.activeTab - 1 + len(tabNames)) % len(tabNames)
+				return nil
+			}},
+		{ID: "toggle_layout", Title: "Toggle tabs and panes layout", Help: "layout", Keys: []string{"L"}, Tab: anyTab,
+			Run: func(m *Model) tea.Cmd { return m.toggleLayout() }},
+		{ID: "grow_split", Title: "Widen the left column", Keys: []string{">"}, Tab: anyTab, Layout: config.LayoutPanes,
+			Run: func(m *Model) tea.Cmd { return m.resizeSplit(5) }},
+		{ID: "shrink_split", Title: "Narrow the left column", Keys: []string{"<"}, Tab: anyTab, Layout: config.LayoutPanes,
+			Run: func(m *Model) tea.Cmd { return m.resizeSplit(-5) }},
+		{ID: "run_all", Title: "Run all linters on the selected files", Tab: anyTab,
+			Run: func(m *Model) tea.Cmd {
+				m.targets = m.explorer.GetSelectedFiles()
+				return m.startRun(m.activeLinters)
+			}},
+		{ID: "run_changed", Title: "Run all linters on changed files", Tab: anyTab,
+			Run: func(m *Model) tea.Cmd { return m.runChanged() }},
+		{ID: "toggle_watch", Title: "Toggle watch mode", Help: "watch", Keys: []string{"ctrl+w"}, Tab: anyTab,
+			Run: func(m *Model) tea.Cmd { return m.toggleWatch() }},
+		{ID: "open_config", Title: "Open config", Tab: anyTab,
+			Run: func(m *Model) tea.Cmd {
+				m.activeTab = 3
+				return nil
+			}},
+
+		// Explorer tab
+		{ID: "explorer_select", Title: "Select file or directory", Help: "select", Keys: []string{" "}, Tab: 0,
+			Run: func(m *Model) tea.Cmd { return m.explorer.ToggleSelected() }},
+		{ID: "explorer_open", Title: "Open file or directory", Help: "open", Keys: []string{"enter"}, Tab: 0,
+			Run: func(m *Model) tea.Cmd {
+				m.explorer.OpenSelected()
+				return nil
+			}},
+		{ID: "explorer_run", Title: "Run linters on selected files", Help: "run", Keys: []string{"r"}, Tab: 0,
+			Run: func(m *Model) tea.Cmd {
+				selectedFiles := m.explorer.GetSelectedFiles()
+				if len(selectedFiles) == 0 && !m.stagedMode {
+					return nil
+				}
+				m.targets = selectedFiles
+				return m.startRun(m.activeLinters)
+			}},
+		{ID: "explorer_select_all", Title: "Select all files in the current directory and below", Help: "select all", Keys: []string{"a"}, Tab: 0,
+			Run: func(m *Model) tea.Cmd { return m.explorer.SelectAll() }},
+		{ID: "explorer_invert", Title: "Invert the selection in the current directory and below", Help: "invert", Keys: []string{"v"}, Tab: 0,
+			Run: func(m *Model) tea.Cmd { return m.explorer.InvertSelection() }},
+		{ID: "explorer_glob", Title: "Add files matching a glob", Help: "add glob", Keys: []string{"g"}, Tab: 0,
+			Run: func(m *Model) tea.Cmd {
+				m.activeTab = 0
+				return m.explorer.PromptGlob()
+			}},
+		{ID: "explorer_tree", Title: "Toggle tree view", Help: "tree", Keys: []string{"T"}, Tab: 0,
+			Run: func(m *Model) tea.Cmd {
+				m.explorer.ToggleTree()
+				return nil
+			}},
+		{ID: "explorer_findings", Title: "Show findings of file or directory", Help: "findings", Keys: []string{"f"}, Tab: 0,
+			Run: func(m *Model) tea.Cmd { return m.explorer.ShowFindings() }},
+		{ID: "explorer_focus_preview", Title: "Focus preview", Help: "focus preview", Keys: []string{"p"}, Tab: 0,
+			Run: func(m *Model) tea.Cmd {
+				m.activeTab = 0
+				m.explorer.FocusPreview()
+				return nil
+			}},
+		{ID: "explorer_toggle_preview", Title: "Toggle preview and selected files", Help: "preview", Keys: []string{"o"}, Tab: 0,
+			Run: func(m *Model) tea.Cmd {
+				m.explorer.TogglePreview()
+				return nil
+			}},
+		{ID: "explorer_staged", Title: "Toggle staged mode", Help: "staged", Keys: []string{"s"}, Tab: 0,
+			Run: func(m *Model) tea.Cmd {
+				m.stagedMode = !m.stagedMode
+				return nil
+			}},
+		{ID: "explorer_ignored", Title: "Toggle ignored files", Help: "ignored", Keys: []string{"i"}, Tab: 0,
+			Run: func(m *Model) tea.Cmd {
+				m.explorer.ToggleIgnored()
+				return nil
+			}},
+
+		// Linters tab
+		{ID: "linters_up", Title: "Highlight previous linter", Help: "up", Keys: []string{"up", "k"}, Tab: 1,
+			Run: func(m *Model) tea.Cmd {
+				if m.selectedTool > 0 {
+					m.selectedTool--
+				}
+				return nil
+			}},
+		{ID: "linters_down", Title: "Highlight next linter", Help: "down", Keys: []string{"down", "j"}, Tab: 1,
+			Run: func(m *Model) tea.Cmd {
+				if m.selectedTool < len(m.activeLinters)-1 {
+					m.selectedTool++
+				}
+				return nil
+			}},
+		{ID: "linters_run", Title: "Run highlighted linter", Help: "run", Keys: []string{"enter"}, Tab: 1,
+			Run: func(m *Model) tea.Cmd {
+				if m.selectedTool < len(m.activeLinters) {
+					return m.startRun([]linters.Linter{m.activeLinters[m.selectedTool]})
+				}
+				return nil
+			}},
+
+		// Results tab
+		{ID: "results_export_html", Title: "Export HTML report", Help: "export HTML", Keys: []string{"e"}, Tab: 2,
+			Run: func(m *Model) tea.Cmd {
+				if len(m.results) == 0 {
+					return nil
+				}
+				return m.exportHTML()
+			}},
+		{ID: "results_copy_markdown", Title: "Copy markdown summary", Help: "copy markdown", Keys: []string{"y"}, Tab: 2,
+			Run: func(m *Model) tea.Cmd {
+				if len(m.results) == 0 {
+					return nil
+				}
+				return m.copyMarkdown()
+			}},
+		{ID: "results_show_all", Title: "Show all results", Help: "all results", Keys: []string{"esc"}, Tab: 2,
+			Run: func(m *Model) tea.Cmd {
+				m.resultsPath = ""
+				m.resultCursor = -1
+				m.updateViewportContent()
+				return nil
+			}},
+		{ID: "results_up", Title: "Select previous finding", Help: "previous", Keys: []string{"up", "k"}, Tab: 2,
+			Run: func(m *Model) tea.Cmd {
+				m.moveResultCursor(-1)
+				return nil
+			}},
+		{ID: "results_down", Title: "Select next finding", Help: "next", Keys: []string{"down", "j"}, Tab: 2,
+			Run: func(m *Model) tea.Cmd {
+				m.moveResultCursor(1)
+				return nil
+			}},
+		{ID: "results_open", Title: "Open selected finding", Help: "open", Keys: []string{"enter"}, Tab: 2,
+			Run: func(m *Model) tea.Cmd {
+				m.openResult()
+				return nil
+			}},
+
+		// Config tab
+		{ID: "config_up", Title: "Highlight previous setting", Help: "up", Keys: []string{"up", "k"}, Tab: 3,
+			Run: func(m *Model) tea.Cmd {
+				m.configEditor.Move(-1)
+				return nil
+			}},
+		{ID: "config_down", Title: "Highlight next setting", Help: "down", Keys: []string{"down", "j"}, Tab: 3,
+			Run: func(m *Model) tea.Cmd {
+				m.configEditor.Move(1)
+				return nil
+			}},
+		{ID: "config_edit", Title: "Toggle or edit highlighted setting", Help: "edit", Keys: []string{"enter", " "}, Tab: 3,
+			Run: func(m *Model) tea.Cmd { return m.editConfigField() }},
+		{ID: "config_save_project", Title: "Save configuration to the project", Help: "save project", Keys: []string{"w"}, Tab: 3,
+			Run: func(m *Model) tea.Cmd {
+				m.previewSave(false)
+				return nil
+			}},
+		{ID: "config_save_user", Title: "Save configuration to the user config", Help: "save user", Keys: []string{"W"}, Tab: 3,
+			Run: func(m *Model) tea.Cmd {
+				m.previewSave(true)
+				return nil
+			}},
+
+		// Doctor tab
+		{ID: "doctor_refresh", Title: "Diagnose the linters again", Help: "check again", Keys: []string{"r"}, Tab: doctorTab,
+			Run: func(m *Model) tea.Cmd {
+				m.doctorChecks = nil
+				return m.runDoctor()
+			}},
+	}
+
+	// Go to each tab
+	for i, name := range tabNames {
+		tab := i
+		actions = append(actions, Action{
+			ID: "go_to_" + strings.ToLower(name), Title: "Go to " + name, Tab: anyTab,
+			Run: func(m *Model) tea.Cmd {
+				m.activeTab = tab
+				return nil
+			},
+		})
+	}
+
+	// Focus each pane of the panes layout
+	for i, name := range []string{"repository", "tools", "explorer", "output"} {
+		index := i
+		actions = append(actions, Action{
+			ID: fmt.Sprintf("focus_pane_%d", i+1), Title: "Focus " + name + " pane",
+			Keys: []string{fmt.Sprint(i + 1)}, Tab: anyTab, Layout: config.LayoutPanes,
+			Run: func(m *Model) tea.Cmd { return m.focusPane(index) },
+		})
+	}
+
+	// Run each linter on the selected files
+	for _, linter := range available {
+		linter := linter
+		actions = append(actions, Action{
+			ID: "run_" + linter.Name(), Title: "Run " + linter.Name(), Tab: anyTab,
+			Run: func(m *Model) tea.Cmd {
+				m.targets = m.explorer.GetSelectedFiles()
+				return m.startRun([]linters.Linter{linter})
+			},
+		})
+	}
+
+	// Switch to each theme
+	for _, theme := range themes {
+		theme := theme
+		actions = append(actions, Action{
+			ID: "theme_" + theme, Title: "Switch theme: " + theme, Tab: anyTab,
+			Run: func(m *Model) tea.Cmd {
+				m.config.UI.Theme = theme
+				ApplyTheme(theme)
+				return nil
+			},
+		})
+	}
+
+	return actions
+}
+
+// bindKeys replaces the keys of the actions with the configured bindings,
+// mapping action IDs to keys. An empty list unbinds an action. It reports
+// unknown actions and keys bound to several actions on the same tab and layout.
+func bindKeys(actions []Action, bindings map[string][]string) error {
+	var problems []string
+
+	for id, keys := range bindings {
+		var action *Action
+		for i := range actions {
+			if actions[i].ID == id {
+				action = &actions[i]
+				break
+			}
+		}
+		if action == nil {
+			problems = append(problems, fmt.Sprintf("unknown action %q", id))
+			continue
+		}
+
+		action.Keys = nil
+		for _, k := range keys {
+			k = strings.TrimSpace(k)
+			if k == "space" {
+				k = " "
+			}
+			if k != "" {
+				action.Keys = append(action.Keys, k)
+			}
+		}
+	}
+
+	// Global actions conflict with the actions of every tab
+	for i := range actions {
+		for j := i + 1; j < len(actions); j++ {
+			a, b := actions[i], actions[j]
+			if a.Tab != b.Tab && a.Tab != anyTab && b.Tab != anyTab {
+				continue
+			}
+			if a.Layout != b.Layout && a.Layout != "" && b.Layout != "" {
+				continue
+			}
+			for _, k := range a.Keys {
+				if slices.Contains(b.Keys, k) {
+					problems = append(problems, fmt.Sprintf("key %q is bound to both %q and %q", keyName(k), a.ID, b.ID))
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid keys: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ValidateKeys checks the keys section of the configuration against the
+// actions of all registered linters, so a binding for a linter that isn't
+// installed on this machine isn't an error
+func ValidateKeys(cfg *config.Config, registry *linters.Registry) error {
+	_, err := newActions(cfg, registry, nil)
+	return err
+}
+
+// newActions builds the action registry with the configured keys. The keys
+// are bound against the actions of all registered linters, but only the
+// available linters get an action to run them.
+func newActions(cfg *config.Config, registry *linters.Registry, available []linters.Linter) ([]Action, error) {
+	actions := append(defaultActions(registry.GetAll(), themeNames(cfg)), profileActions(cfg.ProfileNames())...)
+	err := bindKeys(actions, cfg.Keys)
+
+	unavailable := make(map[string]bool)
+	for _, linter := range registry.GetAll() {
+		unavailable["run_"+linter.Name()] = true
+	}
+	for _, linter := range available {
+		delete(unavailable, "run_"+linter.Name())
+	}
+	actions = slices.DeleteFunc(actions, func(action Action) bool {
+		return unavailable[action.ID]
+	})
+
+	return actions, err
+}
+
+// keysFor returns the keys bound to the action with the given ID
+func keysFor(actions []Action, id string) []string {
+	for _, action := range actions {
+		if action.ID == id {
+			return action.Keys
+		}
+	}
+	return nil
+}
+
+// actionForKey returns the action bound to the key on the active tab, preferring
+// actions of the tab over global ones
+func (m *Model) actionForKey(k string) *Action {
+	var global *Action
+	for i := range m.actions {
+		action := &m.actions[i]
+		if !m.inLayout(*action) {
+			continue
+		}
+		for _, bound := range action.Keys {
+			if bound != k {
+				continue
+			}
+			if action.Tab == m.activeTab {
+				return action
+			}
+			if action.Tab == anyTab && global == nil {
+				global = action
+			}
+		}
+	}
+	return global
+}
+
+// actionByID returns the action with the given ID
+func (m *Model) actionByID(id string) *Action {
+	for i := range m.actions {
+		if m.actions[i].ID == id {
+			return &m.actions[i]
+		}
+	}
+	return nil
+}
+
+// visibleActions returns the actions with help texts available on the active tab
+func (m Model) visibleActions() []Action {
+	var tab, global []Action
+	for _, action := range m.actions {
+		if action.Help == "" || len(action.Keys) == 0 || !m.inLayout(action) {
+			continue
+		}
+		switch action.Tab {
+		case m.activeTab:
+			tab = append(tab, action)
+		case anyTab:
+			global = append(global, action)
+		}
+	}
+	return append(tab, global...)
+}
+
+// inLayout reports whether the action is available in the current layout
+func (m Model) inLayout(action Action) bool {
+	if action.Layout == "" {
+		return true
+	}
+	if m.panesLayout() {
+		return action.Layout == config.LayoutPanes
+	}
+	return action.Layout == config.LayoutTabs
+}
+
+// layoutActions returns the actions available in the current layout
+func (m Model) layoutActions() []Action {
+	var actions []Action
+	for _, action := range m.actions {
+		if m.inLayout(action) {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// themeNames returns the names of the configured themes in alphabetical order
+func themeNames(cfg *config.Config) []string {
+	var themes []string
+	for name := range cfg.UI.Themes {
+		themes = append(themes, name)
+	}
+	sort.Strings(themes)
+	return themes
+}
+
+// cycleTheme switches to the next theme
+func (m *Model) cycleTheme() {
+	themes := themeNames(m.config)
+	if len(themes) == 0 {
+		return
+	}
+
+	// Find current theme index
+	currentIndex := 0
+	for i, name := range themes {
+		if name == m.config.UI.Theme {
+			currentIndex = i
+			break
+		}
+	}
+
+	// Switch to next theme
+	nextIndex := (currentIndex + 1) % len(themes)
+	m.config.UI.Theme = themes[nextIndex]
+	m.configEditor.edits["ui.theme"] = m.config.UI.Theme
+	ApplyTheme(m.config.UI.Theme)
+}
+
+// runChanged runs all linters on the files that differ from HEAD
+func (m *Model) runChanged() tea.Cmd {
+	root := m.explorer.rootDir
+	status, err := git.Status(root)
+	if err != nil {
+		m.status = fmt.Sprintf("Failed to list changed files: %s", err)
+		return nil
+	}
+
+	var files []string
+	for path, badge := range status {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if badge == git.StatusIgnored {
+			continue
+		}
+		if info, err := os.Stat(full); err == nil && !info.IsDir() {
+			files = append(files, full)
+		}
+	}
+	if len(files) == 0 {
+		m.status = "No changed files"
+		return nil
+	}
+
+	sort.Strings(files)
+	m.targets = files
+	return m.startRun(m.activeLinters)
+}
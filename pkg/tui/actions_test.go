@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/linters"
+)
+
+func TestDefaultActionsHaveUniqueIDsAndKeys(t *testing.T) {
+	ids := make(map[string]bool)
+	bound := make(map[int]map[string]string)
+
+	for _, action := range defaultActions(nil, []string{"dark", "light"}) {
+		if ids[action.ID] {
+			t.Errorf("duplicate action ID %q", action.ID)
+		}
+		ids[action.ID] = true
+
+		if action.Run == nil {
+			t.Errorf("action %q has no Run function", action.ID)
+		}
+
+		if bound[action.Tab] == nil {
+			bound[action.Tab] = make(map[string]string)
+		}
+		for _, k := range action.Keys {
+			if other, ok := bound[action.Tab][k]; ok {
+				t.Errorf("key %q is bound to both %q and %q", k, other, action.ID)
+			}
+			bound[action.Tab][k] = action.ID
+		}
+	}
+}
+
+func TestActionForKeyPrefersTabActions(t *testing.T) {
+	m := Model{actions: defaultActions(nil, nil)}
+
+	testCases := []struct {
+		tab  int
+		key  string
+		want string
+	}{
+		{0, "enter", "explorer_open"},
+		{1, "enter", "linters_run"},
+		{2, "e", "results_export_html"},
+		{2, "q", "quit"},
+		{4, "ctrl+k", "command_palette"},
+		{4, "e", ""},
+	}
+
+	for _, tc := range testCases {
+		m.activeTab = tc.tab
+		got := ""
+		if action := m.actionForKey(tc.key); action != nil {
+			got = action.ID
+		}
+		if got != tc.want {
+			t.Errorf("actionForKey(%q) on tab %d = %q, want %q", tc.key, tc.tab, got, tc.want)
+		}
+	}
+}
+
+func TestKeyMapDerivesFromActions(t *testing.T) {
+	m := Model{actions: defaultActions(nil, nil), activeTab: 2}
+
+	var helps []string
+	for _, binding := range m.keyMap().ShortHelp() {
+		helps = append(helps, binding.Help().Key+" "+binding.Help().Desc)
+	}
+
+	want := map[string]bool{"e export HTML": false, "q/ctrl+c quit": false, ":/ctrl+k commands": false}
+	for _, help := range helps {
+		if _, ok := want[help]; ok {
+			want[help] = true
+		}
+		if help == "space select" {
+			t.Errorf("explorer binding %q shown on the results tab", help)
+		}
+	}
+	for help, found := range want {
+		if !found {
+			t.Errorf("help %q missing from %v", help, helps)
+		}
+	}
+}
+
+func TestBindKeys(t *testing.T) {
+	actions := defaultActions(nil, nil)
+	err := bindKeys(actions, map[string][]string{
+		"explorer_run":    {"R", "ctrl+r"},
+		"explorer_select": {"space"},
+		"cycle_theme":     {},
+	})
+	if err != nil {
+		t.Fatalf("bindKeys() error = %v", err)
+	}
+
+	m := Model{actions: actions}
+	for k, want := range map[string]string{"R": "explorer_run", "ctrl+r": "explorer_run", " ": "explorer_select", "r": "", "t": ""} {
+		got := ""
+		if action := m.actionForKey(k); action != nil {
+			got = action.ID
+		}
+		if got != want {
+			t.Errorf("actionForKey(%q) = %q, want %q", k, got, want)
+		}
+	}
+}
+
+func TestBindKeysReportsProblems(t *testing.T) {
+	testCases := []struct {
+		name     string
+		bindings map[string][]string
+		want     string
+	}{
+		{"unknown action", map[string][]string{"launch_rockets": {"x"}}, `unknown action "launch_rockets"`},
+		{"same tab", map[string][]string{"explorer_run": {"a"}}, `key "a" is bound to both "explorer_run" and "explorer_select_all"`},
+		{"global", map[string][]string{"quit": {"e"}}, `key "e" is bound to both "quit" and "results_export_html"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := bindKeys(defaultActions(nil, nil), tc.bindings)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("bindKeys() error = %v, want it to contain %s", err, tc.want)
+			}
+		})
+	}
+
+	// Keys may repeat on different tabs
+	if err := bindKeys(defaultActions(nil, nil), map[string][]string{"results_export_html": {"r"}}); err != nil {
+		t.Errorf("bindKeys() error = %v for a key used on another tab", err)
+	}
+}
+
+// missingLinter is a registered linter whose binary isn't installed
+type missingLinter struct{}
+
+func (missingLinter) Name() string        { return "missing" }
+func (missingLinter) Description() string { return "Not installed" }
+func (missingLinter) Run(ctx context.Context, targets ...string) (*linters.Result, error) {
+	return nil, nil
+}
+func (missingLinter) IsAvailable() bool                              { return false }
+func (missingLinter) FileExtensions() []string                       { return []string{".php"} }
+func (missingLinter) Configure(options map[string]interface{}) error { return nil }
+
+func TestKeysOfUnavailableLinters(t *testing.T) {
+	registry := linters.NewRegistry()
+	registry.Register(missingLinter{})
+	cfg := &config.Config{Keys: map[string][]string{"run_missing": {"M"}}}
+
+	if err := ValidateKeys(cfg, registry); err != nil {
+		t.Fatalf("ValidateKeys() error = %v for a registered linter", err)
+	}
+
+	actions, err := newActions(cfg, registry, registry.GetAvailable())
+	if err != nil {
+		t.Fatalf("newActions() error = %v", err)
+	}
+	m := Model{actions: actions}
+	if action := m.actionByID("run_missing"); action != nil {
+		t.Error("unavailable linter got an action")
+	}
+}
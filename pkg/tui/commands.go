@@ -3,76 +3,218 @@ package tui
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/git"
+	"github.com/crixuamg/pkg/history"
 	"github.com/crixuamg/pkg/linters"
+	"github.com/crixuamg/pkg/report"
+	"github.com/crixuamg/pkg/runner"
 )
 
-// runLinter runs a linter and returns a command
-func (m Model) runLinter(linter linters.Linter) tea.Cmd {
+// runJob runs a job of a linter and returns a command
+func (m Model) runJob(job runner.Job) tea.Cmd {
+	mirror := m.mirror
 	return func() tea.Msg {
 		// Create a context with timeout
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
 
+		// Staged runs happen inside the index mirror
+		if mirror != nil {
+			ctx = linters.WithWorkDir(ctx, mirror.Root)
+		}
+
 		// Run the linter
-		result, err := linter.Run(ctx, m.target)
+		result, err := job.Run(ctx)
 		if err != nil {
 			return errorMsg{err: err.Error()}
 		}
+
+		// Show repository paths instead of mirror paths
+		if mirror != nil {
+			result.Output = mirror.MapOutput(result.Output)
+			result.Error = mirror.MapOutput(result.Error)
+			result.Dir = mirror.MapOutput(result.Dir)
+		}
 		return *result
 	}
 }
 
-// updateViewportContent updates the viewport content based on the results
-func (m *Model) updateViewportContent() {
-	var content strings.Builder
-
-	// Process each result
-	for name, result := range m.results {
-		content.WriteString(titleStyle.Render(fmt.Sprintf("%s Results", name)))
-		content.WriteString("\n\n")
+// prepareStaged replaces the targets by their staged version in a new index
+// mirror. Without selected files all staged files are linted.
+func (m *Model) prepareStaged() error {
+	root := m.explorer.rootDir
+	staged, err := git.StagedFiles(root)
+	if err != nil {
+		return fmt.Errorf("failed to list staged files: %w", err)
+	}
 
-		statusLine := fmt.Sprintf("Completed in %.2fs", result.Duration.Seconds())
-		if result.Success {
-			content.WriteString(successStyle.Render(fmt.Sprintf("✓ %s completed successfully", name)))
-		} else {
-			content.WriteString(errorStyle.Render(fmt.Sprintf("✗ %s found issues", name)))
+	// Restrict to the selected files
+	if len(m.targets) > 0 {
+		selected := make(map[string]bool)
+		for _, target := range m.targets {
+			if rel, err := filepath.Rel(root, target); err == nil {
+				selected[filepath.ToSlash(rel)] = true
+			}
 		}
 
-		content.WriteString(" " + infoStyle.Render(statusLine) + "\n\n")
-
-		lines := linters.ParseOutput(result)
-		if len(lines) > 0 {
-			for _, line := range lines {
-				// Highlight error lines
-				if strings.Contains(strings.ToLower(line), "error") {
-					content.WriteString(errorStyle.Render(line))
-				} else if strings.Contains(strings.ToLower(line), "warning") {
-					content.WriteString(warningStyle.Render(line))
-				} else {
-					content.WriteString(line)
-				}
-				content.WriteString("\n")
+		var files []string
+		for _, file := range staged {
+			if selected[file] {
+				files = append(files, file)
 			}
-		} else {
-			content.WriteString(infoStyle.Render(fmt.Sprintf("No output from %s", name)))
-			content.WriteString("\n")
 		}
+		staged = files
+	}
+
+	if len(staged) == 0 {
+		return fmt.Errorf("no staged files to lint")
+	}
+
+	mirror, err := git.NewIndexMirror(root, staged)
+	if err != nil {
+		return err
+	}
+
+	m.mirror = mirror
+	m.targets = mirror.Paths()
+	return nil
+}
+
+// closeMirror removes the index mirror of a finished staged run
+func (m *Model) closeMirror() {
+	if m.mirror != nil {
+		m.mirror.Close()
+		m.mirror = nil
+	}
+}
+
+// startRun resets previous results and runs the given linters
+func (m *Model) startRun(toRun []linters.Linter) tea.Cmd {
+	if len(toRun) == 0 {
+		return nil
+	}
+
+	// Lint the staged content instead of the working tree
+	m.closeMirror()
+	if m.stagedMode {
+		if err := m.prepareStaged(); err != nil {
+			m.status = err.Error()
+			return nil
+		}
+	}
+
+	// Skip linters that can't process any of the selected files and split
+	// the others by the per-directory overrides and the sub-projects
+	root := m.explorer.rootDir
+	if m.mirror != nil {
+		root = m.mirror.Root
+	}
+	jobs := runner.Plan(m.config, m.workspace, root, toRun, m.targets)
+	if len(jobs) == 0 {
+		m.closeMirror()
+		m.status = "No linter can process the selected files"
+		return nil
+	}
+
+	m.results = make(map[string]*linters.Result)
+	m.pending = len(jobs)
+	m.runStarted = time.Now()
+	m.state = StateRunning
+	m.err = ""
+	m.status = ""
+
+	cmds := []tea.Cmd{m.spinner.Tick}
+	for _, job := range jobs {
+		cmds = append(cmds, m.runJob(job))
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadHistory loads the stored runs
+func (m Model) loadHistory() tea.Cmd {
+	store := m.history
+	return func() tea.Msg {
+		runs, err := store.Load()
+		if err != nil {
+			return historyMsg{err: err.Error()}
+		}
+		return historyMsg{runs: runs}
+	}
+}
+
+// recordRun stores a summary of the current results and reloads the history
+func (m Model) recordRun() tea.Cmd {
+	store := m.history
+	duration := time.Since(m.runStarted)
+
+	results := m.resultList()
 
-		if result.Error != "" {
-			content.WriteString("\n")
-			content.WriteString(errorStyle.Render("Errors:"))
-			content.WriteString("\n")
-			content.WriteString(errorStyle.Render(result.Error))
-			content.WriteString("\n")
+	return func() tea.Msg {
+		root, _ := config.FindGitRoot()
+		commit, _ := git.HeadCommit(root)
+
+		if err := store.Append(history.NewRun(results, commit, duration)); err != nil {
+			return historyMsg{err: err.Error()}
 		}
 
-		content.WriteString("\n")
+		runs, err := store.Load()
+		if err != nil {
+			return historyMsg{err: err.Error()}
+		}
+		return historyMsg{runs: runs}
 	}
+}
 
-	m.viewport.SetContent(content.String())
-	m.viewport.GotoTop()
+// resultList returns the current results sorted by linter name and partition
+func (m Model) resultList() []*linters.Result {
+	var results []*linters.Result
+	for _, result := range m.results {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Label() < results[j].Label()
+	})
+	return results
+}
+
+// exportHTML writes an HTML report of the current results
+func (m Model) exportHTML() tea.Cmd {
+	results := m.resultList()
+	theme := m.config.UI.Themes[m.config.UI.Theme]
+	return func() tea.Msg {
+		root, _ := config.FindGitRoot()
+		path, err := report.New(results, root).WriteHTMLFile(filepath.Join(root, ".lazylint", "report.html"), theme)
+		if err != nil {
+			return errorMsg{err: err.Error()}
+		}
+		return statusMsg{text: fmt.Sprintf("Report written to %s", path)}
+	}
+}
+
+// copyMarkdown copies a markdown summary of the current results to the clipboard
+func (m Model) copyMarkdown() tea.Cmd {
+	results := m.resultList()
+	linkTemplate := m.config.Report.LinkTemplate
+	return func() tea.Msg {
+		root, _ := config.FindGitRoot()
+		commit, _ := git.HeadCommit(root)
+
+		var buf strings.Builder
+		opts := report.MarkdownOptions{LinkTemplate: linkTemplate, Commit: commit}
+		if err := report.New(results, root).WriteMarkdown(&buf, opts); err != nil {
+			return errorMsg{err: err.Error()}
+		}
+		if err := clipboard.WriteAll(buf.String()); err != nil {
+			return errorMsg{err: fmt.Sprintf("failed to copy to clipboard: %s", err)}
+		}
+		return statusMsg{text: "Markdown summary copied to clipboard"}
+	}
 }
@@ -0,0 +1,491 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/crixuamg/pkg/config"
+)
+
+// configField is an editable setting of the Config tab
+type configField struct {
+	linter string // Linter of the setting, empty for the theme
+	option string // "enabled", "path", "args" or "theme"
+}
+
+// ConfigEditor holds the state of the Config tab, which edits the linters and
+// the theme of the running configuration
+type ConfigEditor struct {
+	fields  []configField
+	cursor  int
+	input   textinput.Model
+	editing bool // Whether a path or the args are being typed
+
+	// Settings changed since the last save by dotted key, the only ones a
+	// save writes so that other layers don't leak into the file
+	edits map[string]interface{}
+
+	// A save waiting for confirmation, shown as a diff of the file
+	savePath string
+	diff     []string
+}
+
+// NewConfigEditor creates the editor for the settings of the given linters
+func NewConfigEditor(linterNames []string) *ConfigEditor {
+	names := append([]string{}, linterNames...)
+	sort.Strings(names)
+
+	var fields []configField
+	for _, name := range names {
+		for _, option := range []string{"enabled", "path", "args"} {
+			fields = append(fields, configField{linter: name, option: option})
+		}
+	}
+	fields = append(fields, configField{option: "theme"})
+
+	input := textinput.New()
+	input.Prompt = ""
+	return &ConfigEditor{fields: fields, input: input, edits: make(map[string]interface{})}
+}
+
+// key returns the dotted key of a field, e.g. "linters.phpstan.args"
+func (f configField) key() string {
+	if f.option == "theme" {
+		return "ui.theme"
+	}
+	return "linters." + f.linter + "." + f.option
+}
+
+// Capturing reports whether the editor consumes all key presses, while
+// typing a value or confirming a save
+func (e *ConfigEditor) Capturing() bool {
+	return e.editing || e.diff != nil
+}
+
+// Move moves the cursor by delta fields
+func (e *ConfigEditor) Move(delta int) {
+	e.cursor = max(0, min(e.cursor+delta, len(e.fields)-1))
+}
+
+// selected returns the field under the cursor
+func (e *ConfigEditor) selected() configField {
+	return e.fields[e.cursor]
+}
+
+// linterOptions returns the options of the linter in the running
+// configuration, creating them if needed
+func (m *Model) linterOptions(name string) map[string]interface{} {
+	if m.config.Linters == nil {
+		m.config.Linters = make(map[string]map[string]interface{})
+	}
+	options := m.config.Linters[name]
+	if options == nil {
+		options = make(map[string]interface{})
+		m.config.Linters[name] = options
+	}
+	return options
+}
+
+// configValue renders the current value of a field
+func (m *Model) configValue(field configField) string {
+	if field.option == "theme" {
+		return m.config.UI.Theme
+	}
+
+	options := m.config.Linters[field.linter]
+	switch field.option {
+	case "enabled":
+		if enabled, ok := options["enabled"].(bool); ok && !enabled {
+			return "[ ]"
+		}
+		return "[x]"
+	case "args":
+		return joinArgs(optionArgs(options["args"]))
+	default:
+		path, _ := options["path"].(string)
+		return path
+	}
+}
+
+// editConfigField toggles the enabled flag or the theme under the cursor, or
+// starts typing a path or the args
+func (m *Model) editConfigField() tea.Cmd {
+	editor := m.configEditor
+	field := editor.selected()
+
+	switch field.option {
+	case "theme":
+		m.cycleTheme()
+		return nil
+	case "enabled":
+		options := m.linterOptions(field.linter)
+		enabled, ok := options["enabled"].(bool)
+		options["enabled"] = ok && !enabled
+		editor.edits[field.key()] = options["enabled"]
+		m.reconfigure(field.linter)
+		return nil
+	}
+
+	editor.editing = true
+	editor.input.SetValue(m.configValue(field))
+	editor.input.CursorEnd()
+	return editor.input.Focus()
+}
+
+// updateConfigEditor handles key presses while typing a value or confirming
+// a save
+func (m *Model) updateConfigEditor(msg tea.KeyMsg) tea.Cmd {
+	editor := m.configEditor
+
+	if editor.diff != nil {
+		switch msg.String() {
+		case "enter", "y":
+			m.status = m.writeConfig()
+			editor.diff = nil
+		case "esc", "n", "q":
+			editor.diff = nil
+		}
+		return nil
+	}
+
+	switch msg.String() {
+	case "enter":
+		field := editor.selected()
+		options := m.linterOptions(field.linter)
+		if field.option == "args" {
+			options["args"] = splitArgs(editor.input.Value())
+		} else {
+			options[field.option] = strings.TrimSpace(editor.input.Value())
+		}
+		editor.edits[field.key()] = options[field.option]
+		m.reconfigure(field.linter)
+		fallthrough
+	case "esc":
+		editor.editing = false
+		editor.input.Blur()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	editor.input, cmd = editor.input.Update(msg)
+	return cmd
+}
+
+// reconfigure applies the options of a linter to the live registry and
+// refreshes the list of available linters, which depends on the path
+func (m *Model) reconfigure(name string) {
+	if linter, ok := m.registry.Get(name); ok {
+		if err := linter.Configure(m.config.LinterOptions(name)); err != nil {
+			m.status = fmt.Sprintf("Failed to configure %s: %s", name, err)
+		}
+	}
+	m.refreshLinters()
+}
+
+// refreshLinters lists the linters available with the current configuration
+func (m *Model) refreshLinters() {
+	m.activeLinters = m.registry.GetEnabled(m.config.LinterEnabled)
+	m.selectedTool = max(0, min(m.selectedTool, len(m.activeLinters)-1))
+
+	var names []string
+	for _, linter := range m.activeLinters {
+		names = append(names, linter.Name())
+	}
+	for _, pane := range m.panes {
+		if tools, ok := pane.(*ToolsPane); ok {
+			tools.SetTools(names)
+		}
+	}
+}
+
+// configSavePath returns the file saving to the project or user config writes
+func (m *Model) configSavePath(user bool) (string, error) {
+	if user {
+		return config.UserConfigPath()
+	}
+	if m.configPath != "" {
+		return m.configPath, nil
+	}
+	return "lazylint.yaml", nil
+}
+
+// previewSave shows the changes saving the edited settings would make to the
+// project or user config, to be confirmed before writing
+func (m *Model) previewSave(user bool) {
+	path, err := m.configSavePath(user)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+
+	// A missing file is created
+	editor := m.configEditor
+	current, data, err := config.MarshalSettings(path, editor.edits)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+
+	diff := diffLines(string(current), string(data))
+	if len(editor.edits) == 0 || len(diff) == 0 {
+		m.status = fmt.Sprintf("No changes to save to %s", path)
+		return
+	}
+
+	editor.savePath = path
+	editor.diff = diff
+}
+
+// writeConfig writes the previewed save, returning the status to show
+func (m *Model) writeConfig() string {
+	editor := m.configEditor
+	if err := config.SaveSettings(editor.savePath, editor.edits); err != nil {
+		return fmt.Sprintf("Failed to save configuration: %s", err)
+	}
+	editor.edits = make(map[string]interface{})
+	return fmt.Sprintf("Saved configuration to %s", editor.savePath)
+}
+
+// renderConfigTab renders the config tab content
+func (m Model) renderConfigTab(width int) string {
+	editor := m.configEditor
+	if editor.diff != nil {
+		return m.renderConfigDiff(width)
+	}
+
+	// Add title
+	title := titleStyle.Render("Configuration")
+
+	var b strings.Builder
+	labelStyle := lipgloss.NewStyle().Foreground(muted).Width(10)
+	for i, field := range editor.fields {
+		// Head each linter with its extensions and availability
+		if i == 0 || field.linter != editor.fields[i-1].linter {
+			b.WriteString("\n")
+			b.WriteString(m.configHeading(field))
+			b.WriteString("\n")
+		}
+
+		value := m.configValue(field)
+		if i == editor.cursor && editor.editing {
+			editor.input.Width = max(width-18, 10)
+			value = editor.input.View()
+		}
+
+		line := labelStyle.Render(field.option) + value
+		if i == editor.cursor {
+			b.WriteString(selectedItemStyle.Render("> ") + line)
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+
+	hint := "Enter: Toggle or edit • w: Save to project • W: Save to user config"
+	if m.config.Profile != "" {
+		hint = fmt.Sprintf("The %s profile overrides these settings • ", m.config.Profile) + hint
+	}
+	if editor.editing {
+		hint = "Enter: Apply • Esc: Cancel"
+		if editor.selected().option == "args" {
+			hint += " • Quote arguments with spaces"
+		}
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		b.String(),
+		infoStyle.Render(hint),
+	)
+}
+
+// configHeading renders the heading above the fields of a linter or the theme
+func (m Model) configHeading(field configField) string {
+	if field.linter == "" {
+		return subtitleStyle.Render("Theme") + " " + infoStyle.Render(strings.Join(themeNames(m.config), ", "))
+	}
+
+	heading := subtitleStyle.Render(field.linter)
+	if linter, ok := m.registry.Get(field.linter); ok {
+		heading += " " + infoStyle.Render(strings.Join(linter.FileExtensions(), " "))
+		if !linter.IsAvailable() {
+			heading += " " + warningStyle.Render("not found")
+		}
+	}
+	return heading
+}
+
+// renderConfigDiff renders the changes of a pending save
+func (m Model) renderConfigDiff(width int) string {
+	editor := m.configEditor
+
+	// Leave out what doesn't fit, e.g. when a new file is created
+	lines := editor.diff
+	limit := max(m.height-12, 5)
+	var more string
+	if len(lines) > limit {
+		more = infoStyle.Render(fmt.Sprintf("  ... %d more lines", len(lines)-limit))
+		lines = lines[:limit]
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			b.WriteString(successStyle.Render(line))
+		case strings.HasPrefix(line, "-"):
+			b.WriteString(errorStyle.Render(line))
+		default:
+			b.WriteString(infoStyle.Render(line))
+		}
+		b.WriteString("\n")
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		titleStyle.Render("Save to "+editor.savePath),
+		b.String()+more,
+		infoStyle.Render("Enter: Save • Esc: Cancel"),
+	)
+}
+
+// optionArgs returns the args option of a linter as strings
+func optionArgs(value interface{}) []string {
+	switch args := value.(type) {
+	case []string:
+		return args
+	case []interface{}:
+		result := make([]string, 0, len(args))
+		for _, arg := range args {
+			result = append(result, fmt.Sprint(arg))
+		}
+		return result
+	}
+	return nil
+}
+
+// joinArgs renders args for editing, quoting the ones with spaces
+func joinArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'") {
+			arg = strconv.Quote(arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+// splitArgs splits typed args on spaces, keeping quoted parts together
+func splitArgs(text string) []string {
+	args := []string{}
+	var (
+		current strings.Builder
+		quote   rune
+		started bool
+	)
+	for _, r := range text {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			started = true
+		case r == ' ' || r == '\t':
+			if started {
+				args = append(args, current.String())
+				current.Reset()
+				started = false
+			}
+		default:
+			current.WriteRune(r)
+			started = true
+		}
+	}
+	if started {
+		args = append(args, current.String())
+	}
+	return args
+}
+
+// diffContext is the number of unchanged lines shown around changes
+const diffContext = 2
+
+// diffLines compares two texts line by line, returning the changed lines
+// prefixed with "+" or "-" and some unchanged lines around them. It returns
+// nil when the texts have the same lines.
+func diffLines(before, after string) []string {
+	a := strings.Split(strings.TrimRight(before, "\n"), "\n")
+	b := strings.Split(strings.TrimRight(after, "\n"), "\n")
+	if before == "" {
+		a = nil
+	}
+
+	// Longest common subsequence table, from the end of both texts
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	// Walk the table, marking each line
+	var lines []string
+	changed := false
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, "  "+a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "- "+a[i])
+			changed = true
+			i++
+		default:
+			lines = append(lines, "+ "+b[j])
+			changed = true
+			j++
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	// Keep the unchanged lines close to a change
+	var result []string
+	skipped := false
+	for k, line := range lines {
+		near := false
+		for d := max(0, k-diffContext); d <= min(len(lines)-1, k+diffContext); d++ {
+			if !strings.HasPrefix(lines[d], "  ") {
+				near = true
+				break
+			}
+		}
+		if near {
+			result = append(result, line)
+			skipped = false
+		} else if !skipped {
+			result = append(result, "  ...")
+			skipped = true
+		}
+	}
+	return result
+}
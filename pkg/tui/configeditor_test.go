@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/linters"
+)
+
+func TestSplitArgs(t *testing.T) {
+	testCases := []struct {
+		text string
+		want []string
+	}{
+		{"analyse --level=8", []string{"analyse", "--level=8"}},
+		{`  --standard=PSR12   "--report=full summary" ''`, []string{"--standard=PSR12", "--report=full summary", ""}},
+		{"", []string{}},
+	}
+
+	for _, tc := range testCases {
+		if got := splitArgs(tc.text); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("splitArgs(%q) = %q, want %q", tc.text, got, tc.want)
+		}
+		if got := splitArgs(joinArgs(tc.want)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("splitArgs(joinArgs(%q)) = %q", tc.want, got)
+		}
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\n"
+	after := "a\nb\nc\nd\nE\nf\ng\n"
+
+	want := []string{"  ...", "  c", "  d", "- e", "+ E", "  f", "  g"}
+	if got := diffLines(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffLines = %q, want %q", got, want)
+	}
+
+	if got := diffLines(before, before); got != nil {
+		t.Errorf("Expected no diff for the same texts, got %q", got)
+	}
+	if got := diffLines("", "a\n"); !reflect.DeepEqual(got, []string{"+ a"}) {
+		t.Errorf("diffLines of a new file = %q", got)
+	}
+}
+
+func TestConfigEditor(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := config.DefaultConfig()
+	registry := linters.DefaultRegistry()
+	m := NewModel(cfg, registry)
+	m.SetConfigPath(filepath.Join(t.TempDir(), "lazylint.yaml"))
+	m.activeTab = 3
+
+	// Settings of other layers, such as the layout or the active profile, stay out of the file
+	cfg.UI.Layout = config.LayoutPanes
+	cfg.Profile = "ci"
+	if err := os.WriteFile(m.configPath, []byte("# Shared settings\nreport:\n  link_template: https://example.com/{path} # GitLab\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	key := func(k string) {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter", "esc":
+			msg = tea.KeyMsg{Type: map[string]tea.KeyType{"enter": tea.KeyEnter, "esc": tea.KeyEscape}[k]}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+
+	// The fields start with the enabled flag of the first linter by name
+	if field := m.configEditor.selected(); field != (configField{"eslint", "enabled"}) {
+		t.Fatalf("First field = %+v, want the enabled flag of eslint", field)
+	}
+	key("enter")
+	if cfg.Linters["eslint"]["enabled"] != false {
+		t.Errorf("eslint enabled = %v, want false", cfg.Linters["eslint"]["enabled"])
+	}
+
+	// Editing the args applies them on enter
+	key("j")
+	key("j")
+	key("enter")
+	if !m.configEditor.Capturing() {
+		t.Fatal("Expected the editor to capture keys while typing")
+	}
+	m.configEditor.input.SetValue(`--ext ".js,.ts" src`)
+	key("enter")
+	if got := cfg.Linters["eslint"]["args"]; !reflect.DeepEqual(got, []string{"--ext", ".js,.ts", "src"}) {
+		t.Errorf("eslint args = %q", got)
+	}
+
+	// Saving previews the diff, then writes on confirmation
+	key("w")
+	if m.configEditor.diff == nil {
+		t.Fatalf("Expected a diff to confirm, status %q", m.status)
+	}
+	key("enter")
+	data, err := os.ReadFile(m.configPath)
+	if err != nil {
+		t.Fatalf("Expected the config to be saved: %v", err)
+	}
+	if !strings.Contains(string(data), ".js,.ts") {
+		t.Errorf("Saved config lacks the edited args:\n%s", data)
+	}
+	if !strings.HasPrefix(string(data), "# Shared settings\nreport:\n  link_template: https://example.com/{path} # GitLab\n") {
+		t.Errorf("Saved config should keep its settings and comments:\n%s", data)
+	}
+	if strings.Contains(string(data), "layout") || strings.Contains(string(data), "profile") || strings.Contains(string(data), "phpstan") {
+		t.Errorf("Saved config should hold the edited settings only:\n%s", data)
+	}
+
+	// Saving again has nothing to write
+	key("w")
+	if m.configEditor.diff != nil || !strings.Contains(m.status, "No changes") {
+		t.Errorf("Expected no changes to save, status %q", m.status)
+	}
+}
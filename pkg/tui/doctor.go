@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/crixuamg/pkg/doctor"
+)
+
+// doctorTab is the index of the Doctor tab
+const doctorTab = 5
+
+// doctorMsg is sent when the linters have been diagnosed
+type doctorMsg struct {
+	checks []doctor.Check
+}
+
+// runDoctor diagnoses the registered linters
+func (m Model) runDoctor() tea.Cmd {
+	cfg, registry, ws, root := m.config, m.registry, m.workspace, m.explorer.rootDir
+	return func() tea.Msg {
+		return doctorMsg{checks: doctor.Run(context.Background(), cfg, registry, ws, root)}
+	}
+}
+
+// renderDoctorTab describes how each linter is found and why it can't run
+func (m Model) renderDoctorTab(width int) string {
+	title := titleStyle.Render("Doctor")
+	if m.doctorChecks == nil {
+		return lipgloss.JoinVertical(lipgloss.Left, title, infoStyle.Render("Checking the linters..."))
+	}
+
+	var b strings.Builder
+	for i, check := range m.doctorChecks {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		switch {
+		case check.Usable():
+			b.WriteString(successStyle.Render("✓ " + check.Name))
+		case check.Enabled:
+			b.WriteString(errorStyle.Render("✗ " + check.Name + " can't run"))
+		default:
+			b.WriteString(infoStyle.Render("- " + check.Name + " can't run"))
+		}
+		for _, line := range check.Describe() {
+			b.WriteString("\n")
+			b.WriteString(itemStyle.Render("  " + line))
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, b.String())
+}
@@ -5,11 +5,15 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/crixuamg/pkg/git"
 	"github.com/crixuamg/pkg/linters"
 )
 
@@ -19,6 +23,14 @@ type FileItem struct {
 	name     string
 	isDir    bool
 	selected bool
+	partial  bool
+	status   string
+	counts   findingCounts
+
+	// Tree mode indents items and marks directories as expanded or collapsed
+	depth    int
+	tree     bool
+	expanded bool
 }
 
 // FilterValue implements list.Item interface
@@ -26,13 +38,49 @@ func (i FileItem) FilterValue() string { return i.name }
 
 // Title returns the title of the item
 func (i FileItem) Title() string {
+	var title string
+	if i.tree {
+		title = strings.Repeat("  ", i.depth)
+		if !i.isDir {
+			title += "  "
+		} else if i.expanded {
+			title += "▾ "
+		} else {
+			title += "▸ "
+		}
+	}
+
 	if i.selected {
-		return selectedItemStyle.Render("✓ " + i.name)
+		title += selectedItemStyle.Render("✓ " + i.name)
+	} else if i.partial {
+		title += selectedItemStyle.Render("◐ " + i.name)
+	} else if i.isDir {
+		title += dirStyle.Render("📁 " + i.name)
+	} else {
+		title += fileStyle.Render("📄 " + i.name)
 	}
-	if i.isDir {
-		return dirStyle.Render("📁 " + i.name)
+
+	if i.status != "" {
+		title += " " + statusBadge(i.status)
+	}
+	if counts := i.counts.String(); counts != "" {
+		title += " " + counts
+	}
+	return title
+}
+
+// statusBadge renders a git status badge
+func statusBadge(status string) string {
+	switch status {
+	case git.StatusConflict:
+		return errorStyle.Render(status)
+	case git.StatusModified:
+		return warningStyle.Render(status)
+	case git.StatusAdded:
+		return successStyle.Render(status)
+	default:
+		return infoStyle.Render(status)
 	}
-	return fileStyle.Render("📄 " + i.name)
 }
 
 // Description returns the description of the item
@@ -46,23 +94,41 @@ type Explorer struct {
 	selectedFiles map[string]bool
 	currentDir    string
 	rootDir       string
-	preview       string
+	preview       *Preview
+	previewFocus  bool
+	focusKeys     []string // Keys that focus the preview also leave it
+	itemHeight    int      // Rows taken by a list item, including spacing
 	width         int
 	height        int
 	showPreview   bool
 	registry      *linters.Registry
 	fileFilter    string
+	showIgnored   bool
+	status        map[string]string
+
+	// Glob prompt for adding files to the selection
+	prompt    textinput.Model
+	prompting bool
+
+	// Tree mode shows expandable directories inline
+	treeMode bool
+	expanded map[string]bool
+
+	// Finding counts per file and directory, and findings per file, of the last run
+	counts   map[string]findingCounts
+	findings map[string][]linters.Finding
+}
+
+// findingsFilterMsg asks to show the findings of a file or directory
+type findingsFilterMsg struct {
+	path string
 }
 
 // NewExplorer creates a new explorer
 func NewExplorer(width, height int) *Explorer {
-	// Get git root directory
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
-	out, err := cmd.Output()
-	rootDir := "."
-	if err == nil {
-		rootDir = strings.TrimSpace(string(out))
-	}
+	// Get git root directory, falling back to the current directory
+	cwd, _ := os.Getwd()
+	rootDir := repoRoot(cwd)
 
 	// Create list
 	delegate := list.NewDefaultDelegate()
@@ -79,9 +145,18 @@ func NewExplorer(width, height int) *Explorer {
 	l.Styles.FilterPrompt = infoStyle
 	l.Styles.FilterCursor = infoStyle
 
+	// Create the glob prompt
+	prompt := textinput.New()
+	prompt.Prompt = "Add files matching: "
+	prompt.Placeholder = "src/**/*.php"
+	prompt.PromptStyle = infoStyle
+
 	e := &Explorer{
 		list:          l,
-		selectedFiles: make(map[string]bool),
+		selectedFiles: loadSelection(rootDir),
+		prompt:        prompt,
+		itemHeight:    delegate.Height() + delegate.Spacing(),
+		expanded:      make(map[string]bool),
 		currentDir:    rootDir,
 		rootDir:       rootDir,
 		width:         width,
@@ -94,126 +169,395 @@ func NewExplorer(width, height int) *Explorer {
 	return e
 }
 
+// repoRoot returns the root of the git repository containing dir, or dir
+// itself outside a repository
+func repoRoot(dir string) string {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return dir
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// SelectTargets replaces the selection with the given files and the lintable
+// files below the given directories, and shows the first target. When the
+// first target lies outside the root, the explorer moves to its repository.
+func (e *Explorer) SelectTargets(paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+
+	first := paths[0]
+	info, err := os.Stat(first)
+	dir := first
+	if err != nil || !info.IsDir() {
+		dir = filepath.Dir(first)
+	}
+
+	if rel, err := filepath.Rel(e.rootDir, dir); err != nil || strings.HasPrefix(rel, "..") {
+		e.rootDir = repoRoot(dir)
+		e.expanded = make(map[string]bool)
+	}
+	e.currentDir = dir
+
+	e.selectedFiles = make(map[string]bool)
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			for _, file := range e.lintableFiles(path) {
+				e.selectedFiles[file] = true
+			}
+		} else {
+			e.selectedFiles[path] = true
+		}
+	}
+	e.loadFiles()
+
+	// Put the cursor on a file target and preview it
+	if dir != first {
+		for i, item := range e.list.Items() {
+			if file, ok := item.(FileItem); ok && file.path == first {
+				e.list.Select(i)
+			}
+		}
+		e.preview = NewPreview(first, e.findings[first])
+		e.preview.SetSize(e.previewSize())
+		e.showPreview = true
+	}
+}
+
 // loadFiles loads files from the current directory
 func (e *Explorer) loadFiles() {
 	var items []list.Item
 
-	// Get git tracked files
-	cmd := exec.Command("git", "ls-files", "--full-name", e.currentDir)
-	out, err := cmd.Output()
-	if err == nil {
-		files := strings.Split(strings.TrimSpace(string(out)), "\n")
+	// Refresh the git status badges
+	e.status = make(map[string]string)
+	if status, err := git.Status(e.rootDir); err == nil {
+		for path, badge := range status {
+			e.status[filepath.Join(e.rootDir, filepath.FromSlash(path))] = badge
+		}
+	}
 
-		// Add parent directory if not at root
-		if e.currentDir != e.rootDir {
-			items = append(items, FileItem{
-				path:  filepath.Dir(e.currentDir),
-				name:  "..",
-				isDir: true,
-			})
+	if e.treeMode {
+		e.loadTree()
+		return
+	}
+
+	// Add parent directory if not at root
+	if e.currentDir != e.rootDir {
+		items = append(items, FileItem{
+			path:  filepath.Dir(e.currentDir),
+			name:  "..",
+			isDir: true,
+		})
+	}
+
+	// Process tracked, untracked and optionally ignored files
+	dirs := make(map[string]int)
+	lintable := make(map[string]int)
+	selected := make(map[string]int)
+	files, ignored := listRepoFiles(e.rootDir, e.showIgnored)
+	for _, fullPath := range files {
+		// Check if it's in current directory or subdirectory
+		rel, err := filepath.Rel(e.currentDir, fullPath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
 		}
 
-		// Process files
-		dirs := make(map[string]bool)
-		for _, file := range files {
-			if file == "" {
-				continue
-			}
+		// Filter by extension if registry is set
+		if !e.isListed(fullPath) {
+			continue
+		}
 
-			// Get full path
-			fullPath := filepath.Join(e.rootDir, file)
+		badge := e.status[fullPath]
+		if ignored[fullPath] {
+			badge = git.StatusIgnored
+		}
 
-			// Check if it's in current directory or subdirectory
-			rel, err := filepath.Rel(e.currentDir, fullPath)
-			if err != nil || strings.HasPrefix(rel, "..") {
+		// If it's a direct child, add it
+		parts := strings.Split(rel, string(os.PathSeparator))
+		if len(parts) == 1 {
+			info, err := os.Stat(fullPath)
+			if err != nil {
 				continue
 			}
 
-			// Filter by extension if registry is set
-			if e.registry != nil && !strings.HasPrefix(filepath.Base(fullPath), ".") {
-				ext := filepath.Ext(fullPath)
-				if ext != "" && len(e.registry.GetForExtension(ext)) == 0 {
-					// Skip files that don't match any linter
-					continue
+			items = append(items, FileItem{
+				path:     fullPath,
+				name:     filepath.Base(fullPath),
+				isDir:    info.IsDir(),
+				selected: e.selectedFiles[fullPath],
+				status:   badge,
+				counts:   e.counts[fullPath],
+			})
+		} else if len(parts) > 1 {
+			// If it's in a subdirectory, add the directory if not already added
+			dirPath := filepath.Join(e.currentDir, parts[0])
+
+			// Count selected files to mark the directory as (partially) selected
+			if e.isLintable(fullPath) {
+				lintable[dirPath]++
+				if e.selectedFiles[fullPath] {
+					selected[dirPath]++
 				}
 			}
 
-			// If it's a direct child, add it
-			parts := strings.Split(rel, string(os.PathSeparator))
-			if len(parts) == 1 {
-				info, err := os.Stat(fullPath)
-				if err != nil {
-					continue
-				}
-
+			index, ok := dirs[dirPath]
+			if !ok {
+				dirs[dirPath] = len(items)
 				items = append(items, FileItem{
-					path:     fullPath,
-					name:     filepath.Base(fullPath),
-					isDir:    info.IsDir(),
-					selected: e.selectedFiles[fullPath],
+					path:   dirPath,
+					name:   parts[0],
+					isDir:  true,
+					status: badge,
+					counts: e.counts[dirPath],
 				})
-			} else if len(parts) > 1 {
-				// If it's in a subdirectory, add the directory if not already added
-				dirPath := filepath.Join(e.currentDir, parts[0])
-				if !dirs[dirPath] {
-					dirs[dirPath] = true
-					items = append(items, FileItem{
-						path:  dirPath,
-						name:  parts[0],
-						isDir: true,
-					})
+				continue
+			}
+
+			// Directories show the most relevant status of their children
+			dir := items[index].(FileItem)
+			if git.StatusPriority(badge) > git.StatusPriority(dir.status) {
+				dir.status = badge
+				items[index] = dir
+			}
+		}
+	}
+
+	// Mark directories whose lintable files are selected
+	for dirPath, index := range dirs {
+		if selected[dirPath] == 0 {
+			continue
+		}
+		dir := items[index].(FileItem)
+		dir.selected = selected[dirPath] == lintable[dirPath]
+		dir.partial = !dir.selected
+		items[index] = dir
+	}
+
+	e.list.SetItems(items)
+}
+
+// loadTree loads all files below the root as an expandable tree
+func (e *Explorer) loadTree() {
+	files, ignored := listRepoFiles(e.rootDir, e.showIgnored)
+
+	var listed []string
+	badges := make(map[string]string)
+	lintable := make(map[string]int)
+	selected := make(map[string]int)
+	for _, fullPath := range files {
+		if !e.isListed(fullPath) {
+			continue
+		}
+		listed = append(listed, fullPath)
+
+		badge := e.status[fullPath]
+		if ignored[fullPath] {
+			badge = git.StatusIgnored
+		}
+		badges[fullPath] = badge
+
+		// Directories show the most relevant status and selection of their children
+		for dir := filepath.Dir(fullPath); dir != e.rootDir && strings.HasPrefix(dir, e.rootDir); dir = filepath.Dir(dir) {
+			if git.StatusPriority(badge) > git.StatusPriority(badges[dir]) {
+				badges[dir] = badge
+			}
+			if e.isLintable(fullPath) {
+				lintable[dir]++
+				if e.selectedFiles[fullPath] {
+					selected[dir]++
 				}
 			}
 		}
 	}
 
+	var items []list.Item
+	var addNodes func(node *treeNode, depth int)
+	addNodes = func(node *treeNode, depth int) {
+		for _, child := range node.sortedChildren() {
+			item := FileItem{
+				path:     child.path,
+				name:     filepath.Base(child.path),
+				isDir:    child.isDir,
+				selected: e.selectedFiles[child.path],
+				status:   badges[child.path],
+				counts:   e.counts[child.path],
+				depth:    depth,
+				tree:     true,
+				expanded: e.expanded[child.path],
+			}
+			if child.isDir && selected[child.path] > 0 {
+				item.selected = selected[child.path] == lintable[child.path]
+				item.partial = !item.selected
+			}
+			items = append(items, item)
+
+			if child.isDir && item.expanded {
+				addNodes(child, depth+1)
+			}
+		}
+	}
+	addNodes(buildTree(e.rootDir, listed), 0)
+
 	e.list.SetItems(items)
 }
 
+// isListed reports whether the file is shown, hiding files no registered linter handles
+func (e *Explorer) isListed(path string) bool {
+	if e.registry == nil || strings.HasPrefix(filepath.Base(path), ".") {
+		return true
+	}
+	ext := filepath.Ext(path)
+	return ext == "" || len(e.registry.GetForExtension(ext)) > 0
+}
+
+// SetFindings decorates the files and directories with the finding counts of the results
+func (e *Explorer) SetFindings(results []*linters.Result) {
+	e.counts = countFindings(results, e.rootDir)
+	e.findings = make(map[string][]linters.Finding)
+	for _, finding := range findingsBelow(results, e.rootDir, e.rootDir) {
+		e.findings[finding.File] = append(e.findings[finding.File], finding)
+	}
+	if e.preview != nil {
+		e.preview.SetFindings(e.findings[e.preview.path])
+	}
+	e.loadFiles()
+}
+
+// SetSize sets the space available to the list and the preview
+func (e *Explorer) SetSize(width, height int) {
+	e.width = width
+	e.height = height
+	e.list.SetWidth(width / 2)
+	e.list.SetHeight(height - 4)
+}
+
+// previewSize returns the size of the preview content
+func (e *Explorer) previewSize() (int, int) {
+	return e.width/2 - 8, e.height - 8
+}
+
+// isLintable reports whether a registered linter can process the file
+func (e *Explorer) isLintable(path string) bool {
+	if e.registry == nil {
+		return true
+	}
+	return len(e.registry.GetForExtension(filepath.Ext(path))) > 0
+}
+
+// lintableFiles returns the lintable files below dir
+func (e *Explorer) lintableFiles(dir string) []string {
+	files, _ := listRepoFiles(e.rootDir, e.showIgnored)
+
+	var result []string
+	for _, file := range files {
+		rel, err := filepath.Rel(dir, file)
+		if err != nil || strings.HasPrefix(rel, "..") || !e.isLintable(file) {
+			continue
+		}
+		result = append(result, file)
+	}
+	return result
+}
+
+// toggleFiles selects all given files, or deselects them when all are already selected
+func (e *Explorer) toggleFiles(files []string) {
+	all := true
+	for _, file := range files {
+		if !e.selectedFiles[file] {
+			all = false
+			break
+		}
+	}
+
+	for _, file := range files {
+		if all {
+			delete(e.selectedFiles, file)
+		} else {
+			e.selectedFiles[file] = true
+		}
+	}
+}
+
+// selectGlob adds the lintable files matching the pattern, relative to the root, to the selection
+func (e *Explorer) selectGlob(pattern string) int {
+	pattern = strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(pattern)), "/")
+	if pattern == "" {
+		return 0
+	}
+
+	count := 0
+	for _, file := range e.lintableFiles(e.rootDir) {
+		rel, err := filepath.Rel(e.rootDir, file)
+		if err == nil && matchGlob(pattern, filepath.ToSlash(rel)) {
+			e.selectedFiles[file] = true
+			count++
+		}
+	}
+	return count
+}
+
+// selectionChanged persists the selection and refreshes the list. A failed
+// save is reported in the status bar.
+func (e *Explorer) selectionChanged() tea.Cmd {
+	e.loadFiles()
+	if err := saveSelection(e.rootDir, e.selectedFiles); err != nil {
+		return func() tea.Msg {
+			return statusMsg{text: fmt.Sprintf("failed to save the selection: %s", err)}
+		}
+	}
+	return nil
+}
+
+// Capturing reports whether the explorer consumes all key presses, e.g.
+// while typing a glob or a filter, or scrolling the preview
+func (e *Explorer) Capturing() bool {
+	return e.prompting || e.previewFocus || e.list.FilterState() == list.Filtering
+}
+
 // Update updates the explorer
 func (e *Explorer) Update(msg tea.Msg) (*Explorer, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		e.width = msg.Width
-		e.height = msg.Height
-		e.list.SetWidth(msg.Width / 2)
-		e.list.SetHeight(msg.Height - 4)
+		e.SetSize(msg.Width, msg.Height)
 
 	case tea.KeyMsg:
-		// Handle custom keybindings
-		keyMsg := msg
-		switch keyMsg.String() {
-		case "enter":
-			if i, ok := e.list.SelectedItem().(FileItem); ok {
-				if i.isDir {
-					e.currentDir = i.path
-					e.loadFiles()
-					e.preview = ""
-					return e, nil
-				} else {
-					// Load file preview
-					content, err := os.ReadFile(i.path)
-					if err == nil {
-						e.preview = string(content)
-					} else {
-						e.preview = fmt.Sprintf("Error loading file: %s", err)
-					}
-				}
+		// The glob prompt takes all keys while open
+		if e.prompting {
+			switch msg.String() {
+			case "enter":
+				e.prompting = false
+				e.prompt.Blur()
+				e.selectGlob(e.prompt.Value())
+				e.prompt.SetValue("")
+				return e, e.selectionChanged()
+			case "esc":
+				e.prompting = false
+				e.prompt.Blur()
+				e.prompt.SetValue("")
+				return e, nil
 			}
-		case " ":
-			if i, ok := e.list.SelectedItem().(FileItem); ok && !i.isDir {
-				// Toggle selection
-				if e.selectedFiles[i.path] {
-					delete(e.selectedFiles, i.path)
-				} else {
-					e.selectedFiles[i.path] = true
-				}
-				e.loadFiles()
+			e.prompt, cmd = e.prompt.Update(msg)
+			return e, cmd
+		}
+
+		// Leave keys to the list while filtering
+		if e.list.FilterState() == list.Filtering {
+			break
+		}
+
+		// Scroll the focused preview
+		if e.previewFocus {
+			if msg.String() == "esc" || slices.Contains(e.focusKeys, msg.String()) {
+				e.previewFocus = false
+			} else {
+				e.preview.Update(msg)
 			}
-		case "tab":
-			e.showPreview = !e.showPreview
+			return e, nil
 		}
 	}
 
@@ -222,15 +566,137 @@ func (e *Explorer) Update(msg tea.Msg) (*Explorer, tea.Cmd) {
 	return e, cmd
 }
 
+// OpenSelected enters the directory under the cursor, expands or collapses it
+// in tree mode, or previews the file under the cursor
+func (e *Explorer) OpenSelected() {
+	i, ok := e.list.SelectedItem().(FileItem)
+	if !ok {
+		return
+	}
+
+	if i.isDir && e.treeMode {
+		// Expand or collapse the directory in place
+		index := e.list.Index()
+		e.expanded[i.path] = !e.expanded[i.path]
+		e.loadFiles()
+		e.list.Select(index)
+	} else if i.isDir {
+		e.currentDir = i.path
+		e.loadFiles()
+		e.preview = nil
+	} else {
+		// Load file preview
+		e.preview = NewPreview(i.path, e.findings[i.path])
+		e.preview.SetSize(e.previewSize())
+	}
+}
+
+// OpenFile previews the file with the cursor on the given line and focuses the preview
+func (e *Explorer) OpenFile(path string, line int) {
+	e.preview = NewPreview(path, e.findings[path])
+	e.preview.SetSize(e.previewSize())
+	e.preview.moveCursor(line - 1)
+	e.showPreview = true
+	e.previewFocus = true
+}
+
+// ToggleSelected toggles the file under the cursor, or all lintable files
+// beneath the directory under the cursor
+func (e *Explorer) ToggleSelected() tea.Cmd {
+	i, ok := e.list.SelectedItem().(FileItem)
+	if !ok || i.name == ".." {
+		return nil
+	}
+
+	if i.isDir {
+		e.toggleFiles(e.lintableFiles(i.path))
+	} else if e.selectedFiles[i.path] {
+		delete(e.selectedFiles, i.path)
+	} else {
+		e.selectedFiles[i.path] = true
+	}
+	return e.selectionChanged()
+}
+
+// SelectAll selects all lintable files in the current directory and below
+func (e *Explorer) SelectAll() tea.Cmd {
+	for _, file := range e.lintableFiles(e.currentDir) {
+		e.selectedFiles[file] = true
+	}
+	return e.selectionChanged()
+}
+
+// InvertSelection inverts the selection in the current directory and below
+func (e *Explorer) InvertSelection() tea.Cmd {
+	for _, file := range e.lintableFiles(e.currentDir) {
+		if e.selectedFiles[file] {
+			delete(e.selectedFiles, file)
+		} else {
+			e.selectedFiles[file] = true
+		}
+	}
+	return e.selectionChanged()
+}
+
+// PromptGlob opens the prompt for a glob of files to add to the selection
+func (e *Explorer) PromptGlob() tea.Cmd {
+	e.prompting = true
+	return e.prompt.Focus()
+}
+
+// TogglePreview switches between the preview and the list of selected files
+func (e *Explorer) TogglePreview() {
+	e.showPreview = !e.showPreview
+}
+
+// FocusPreview focuses the preview to scroll through the file
+func (e *Explorer) FocusPreview() {
+	if e.preview != nil && e.showPreview {
+		e.previewFocus = true
+	}
+}
+
+// ToggleIgnored toggles showing ignored files
+func (e *Explorer) ToggleIgnored() {
+	e.showIgnored = !e.showIgnored
+	e.loadFiles()
+}
+
+// ToggleTree switches between the tree and the flat directory listing
+func (e *Explorer) ToggleTree() {
+	e.treeMode = !e.treeMode
+	e.currentDir = e.rootDir
+	e.loadFiles()
+	e.list.Select(0)
+}
+
+// ShowFindings asks to show the findings of the file or directory under the cursor
+func (e *Explorer) ShowFindings() tea.Cmd {
+	i, ok := e.list.SelectedItem().(FileItem)
+	if !ok || i.name == ".." || len(e.counts) == 0 {
+		return nil
+	}
+	path := i.path
+	return func() tea.Msg { return findingsFilterMsg{path: path} }
+}
+
 // View renders the explorer
 func (e *Explorer) View() string {
 	// Create file list view
 	fileList := e.list.View()
+	if e.prompting {
+		fileList = lipgloss.JoinVertical(lipgloss.Left, e.prompt.View(), fileList)
+	}
 
 	// Create preview view
 	var preview string
-	if e.showPreview && e.preview != "" {
-		preview = previewStyle.Width(e.width/2 - 4).Render(e.preview)
+	if e.showPreview && e.preview != nil {
+		e.preview.SetSize(e.previewSize())
+		style := previewStyle
+		if e.previewFocus {
+			style = style.BorderForeground(primary)
+		}
+		preview = style.Width(e.width/2 - 4).Render(e.preview.View(e.previewFocus))
 	} else if e.showPreview {
 		preview = previewStyle.Width(e.width/2 - 4).Render("Select a file to preview")
 	} else {
@@ -242,8 +708,12 @@ func (e *Explorer) View() string {
 		if len(e.selectedFiles) == 0 {
 			selectedList.WriteString("No files selected")
 		} else {
-			for path := range e.selectedFiles {
-				selectedList.WriteString(fmt.Sprintf("- %s\n", filepath.Base(path)))
+			for _, path := range e.GetSelectedFiles() {
+				rel, err := filepath.Rel(e.rootDir, path)
+				if err != nil {
+					rel = path
+				}
+				selectedList.WriteString(fmt.Sprintf("- %s\n", rel))
 			}
 		}
 		preview = previewStyle.Width(e.width/2 - 4).Render(selectedList.String())
@@ -259,20 +729,21 @@ func (e *Explorer) GetSelectedFiles() []string {
 	for path := range e.selectedFiles {
 		files = append(files, path)
 	}
+	sort.Strings(files)
 	return files
 }
 
 // Additional styles for the explorer
 var (
 	dirStyle = lipgloss.NewStyle().
-		Foreground(primary)
+			Foreground(primary)
 
 	fileStyle = lipgloss.NewStyle().
-		Foreground(text)
+			Foreground(text)
 
 	previewStyle = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(borderClr).
-		Padding(1, 2).
-		Margin(0, 0, 0, 2)
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(borderClr).
+			Padding(1, 2).
+			Margin(0, 0, 0, 2)
 )
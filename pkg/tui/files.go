@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/crixuamg/pkg/git"
+)
+
+// ignoreFileName is the ignore file honored outside git repositories
+const ignoreFileName = ".lazylintignore"
+
+// listRepoFiles returns the absolute paths of the files below root. In a git
+// repository these are the tracked and untracked files, plus ignored files
+// when showIgnored is set; the returned set holds the ignored ones. Outside
+// git the file system is walked, honoring .lazylintignore.
+func listRepoFiles(root string, showIgnored bool) ([]string, map[string]bool) {
+	ignoredSet := make(map[string]bool)
+
+	if git.IsRepo(root) {
+		tracked, err := git.TrackedFiles(root)
+		if err == nil {
+			untracked, _ := git.UntrackedFiles(root, false)
+			files := append(tracked, untracked...)
+
+			paths := make([]string, 0, len(files))
+			for _, file := range files {
+				paths = append(paths, filepath.Join(root, filepath.FromSlash(file)))
+			}
+
+			if showIgnored {
+				ignored, _ := git.UntrackedFiles(root, true)
+				for _, file := range ignored {
+					path := filepath.Join(root, filepath.FromSlash(file))
+					ignoredSet[path] = true
+					paths = append(paths, path)
+				}
+			}
+			return paths, ignoredSet
+		}
+	}
+
+	return walkFiles(root, showIgnored), ignoredSet
+}
+
+// walkFiles lists the files below root, skipping VCS directories and the
+// patterns from .lazylintignore unless showIgnored is set
+func walkFiles(root string, showIgnored bool) []string {
+	var patterns []string
+	if !showIgnored {
+		patterns = loadIgnorePatterns(filepath.Join(root, ignoreFileName))
+	}
+
+	var paths []string
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == "." {
+			return nil
+		}
+
+		if d.IsDir() && (d.Name() == ".git" || d.Name() == ".hg" || d.Name() == ".svn") {
+			return filepath.SkipDir
+		}
+
+		if matchIgnore(patterns, filepath.ToSlash(rel), d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !d.IsDir() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+
+	return paths
+}
+
+// loadIgnorePatterns reads gitignore-style patterns from the given file
+func loadIgnorePatterns(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns
+}
+
+// matchIgnore reports whether the slash-separated relative path matches one
+// of the patterns. Patterns ending in "/" only match directories, patterns
+// containing a "/" are anchored to the root, others match any path segment.
+// A leading "!" re-includes a previously ignored path.
+func matchIgnore(patterns []string, rel string, isDir bool) bool {
+	ignored := false
+	for _, pattern := range patterns {
+		negate := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(pattern, "!")
+
+		if strings.HasSuffix(pattern, "/") {
+			if !isDir {
+				continue
+			}
+			pattern = strings.TrimSuffix(pattern, "/")
+		}
+
+		matched := false
+		if strings.Contains(pattern, "/") {
+			matched, _ = filepath.Match(strings.TrimPrefix(pattern, "/"), rel)
+		} else {
+			for _, segment := range strings.Split(rel, "/") {
+				if ok, _ := filepath.Match(pattern, segment); ok {
+					matched = true
+					break
+				}
+			}
+		}
+
+		if matched {
+			ignored = !negate
+		}
+	}
+	return ignored
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestMatchIgnore(t *testing.T) {
+	patterns := []string{"*.log", "vendor/", "/docs/*.md", "!keep.log"}
+
+	testCases := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"app.log", false, true},
+		{"src/debug.log", false, true},
+		{"keep.log", false, false},
+		{"vendor", true, true},
+		{"vendor", false, false},
+		{"docs/readme.md", false, true},
+		{"src/docs/readme.md", false, false},
+		{"src/main.php", false, false},
+	}
+
+	for _, tc := range testCases {
+		if got := matchIgnore(patterns, tc.path, tc.isDir); got != tc.want {
+			t.Errorf("matchIgnore(%q, dir=%t) = %t, want %t", tc.path, tc.isDir, got, tc.want)
+		}
+	}
+}
+
+func TestWalkFilesHonorsIgnoreFile(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		ignoreFileName:     "vendor/\n*.tmp\n",
+		"src/App.php":      "<?php\n",
+		"src/cache.tmp":    "",
+		"vendor/lib/X.php": "<?php\n",
+		".git/HEAD":        "ref: refs/heads/main\n",
+	}
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	var rel []string
+	for _, path := range walkFiles(root, false) {
+		r, _ := filepath.Rel(root, path)
+		rel = append(rel, filepath.ToSlash(r))
+	}
+	sort.Strings(rel)
+
+	want := []string{ignoreFileName, "src/App.php"}
+	if len(rel) != len(want) || rel[0] != want[0] || rel[1] != want[1] {
+		t.Errorf("walkFiles = %v, want %v", rel, want)
+	}
+
+	if all := walkFiles(root, true); len(all) != 4 {
+		t.Errorf("Expected 4 files when showing ignored files, got %d", len(all))
+	}
+}
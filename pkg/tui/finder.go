@@ -0,0 +1,214 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// finderLimit is the maximum number of matches shown by the finder
+const finderLimit = 200
+
+// finderRunMsg asks to run the linters on the files picked in the finder
+type finderRunMsg struct {
+	files []string
+}
+
+// Finder is an overlay that fuzzy-searches the lintable files of the repository
+type Finder struct {
+	input   textinput.Model
+	root    string
+	files   []string // Paths relative to root
+	matches fuzzy.Matches
+	cursor  int
+	marked  map[string]bool
+	active  bool
+	width   int
+	height  int
+}
+
+// NewFinder creates a closed finder
+func NewFinder() *Finder {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "Search files"
+	input.PromptStyle = infoStyle
+
+	return &Finder{input: input, marked: make(map[string]bool)}
+}
+
+// Open shows the finder for the given absolute file paths below root
+func (f *Finder) Open(root string, files []string) tea.Cmd {
+	f.root = root
+	f.files = f.files[:0]
+	for _, file := range files {
+		if rel, err := filepath.Rel(root, file); err == nil {
+			f.files = append(f.files, filepath.ToSlash(rel))
+		}
+	}
+
+	f.active = true
+	f.marked = make(map[string]bool)
+	f.input.SetValue("")
+	f.search()
+	return f.input.Focus()
+}
+
+// Close hides the finder
+func (f *Finder) Close() {
+	f.active = false
+	f.input.Blur()
+}
+
+// Active reports whether the finder is shown
+func (f *Finder) Active() bool {
+	return f.active
+}
+
+// SetSize sets the size of the overlay
+func (f *Finder) SetSize(width, height int) {
+	f.width = width
+	f.height = height
+}
+
+// search updates the matches for the current query. An empty query lists all files.
+func (f *Finder) search() {
+	query := f.input.Value()
+	if query == "" {
+		f.matches = make(fuzzy.Matches, 0, len(f.files))
+		for i, file := range f.files {
+			f.matches = append(f.matches, fuzzy.Match{Str: file, Index: i})
+		}
+	} else {
+		f.matches = fuzzy.Find(query, f.files)
+	}
+
+	if len(f.matches) > finderLimit {
+		f.matches = f.matches[:finderLimit]
+	}
+	f.cursor = max(0, min(f.cursor, len(f.matches)-1))
+}
+
+// picked returns the absolute paths of the marked files, or of the file
+// under the cursor when none are marked
+func (f *Finder) picked() []string {
+	var files []string
+	for _, file := range f.files {
+		if f.marked[file] {
+			files = append(files, filepath.Join(f.root, filepath.FromSlash(file)))
+		}
+	}
+	if len(files) == 0 && f.cursor < len(f.matches) {
+		files = append(files, filepath.Join(f.root, filepath.FromSlash(f.matches[f.cursor].Str)))
+	}
+	return files
+}
+
+// Update handles key presses while the finder is open
+func (f *Finder) Update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		f.Close()
+		return nil
+	case "up", "ctrl+p":
+		f.cursor = max(f.cursor-1, 0)
+		return nil
+	case "down", "ctrl+n":
+		f.cursor = min(f.cursor+1, max(len(f.matches)-1, 0))
+		return nil
+	case "tab":
+		// Mark the file under the cursor and move on
+		if f.cursor < len(f.matches) {
+			file := f.matches[f.cursor].Str
+			if f.marked[file] {
+				delete(f.marked, file)
+			} else {
+				f.marked[file] = true
+			}
+			f.cursor = min(f.cursor+1, len(f.matches)-1)
+		}
+		return nil
+	case "enter":
+		files := f.picked()
+		f.Close()
+		if len(files) == 0 {
+			return nil
+		}
+		return func() tea.Msg { return finderRunMsg{files: files} }
+	}
+
+	var cmd tea.Cmd
+	previous := f.input.Value()
+	f.input, cmd = f.input.Update(msg)
+	if f.input.Value() != previous {
+		f.cursor = 0
+		f.search()
+	}
+	return cmd
+}
+
+// highlightMatch renders the path with the matched characters emphasized
+func highlightMatch(match fuzzy.Match) string {
+	matched := make(map[int]bool, len(match.MatchedIndexes))
+	for _, index := range match.MatchedIndexes {
+		matched[index] = true
+	}
+
+	highlight := lipgloss.NewStyle().Foreground(primary).Bold(true)
+	plain := lipgloss.NewStyle().Foreground(text)
+
+	var b strings.Builder
+	for i, r := range match.Str {
+		if matched[i] {
+			b.WriteString(highlight.Render(string(r)))
+		} else {
+			b.WriteString(plain.Render(string(r)))
+		}
+	}
+	return b.String()
+}
+
+// View renders the finder
+func (f *Finder) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Find Files"))
+	b.WriteString("\n")
+	b.WriteString(f.input.View())
+	b.WriteString("\n\n")
+
+	// Keep the cursor within the visible rows
+	rows := max(f.height-8, 1)
+	start := max(0, f.cursor-rows+1)
+	end := min(start+rows, len(f.matches))
+
+	if len(f.matches) == 0 {
+		b.WriteString(infoStyle.Render("No matching files"))
+		b.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		match := f.matches[i]
+
+		prefix := "  "
+		if f.marked[match.Str] {
+			prefix = selectedItemStyle.Render("✓ ")
+		}
+		line := prefix + highlightMatch(match)
+		if i == f.cursor {
+			line = selectedItemStyle.Render("> ") + line
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(infoStyle.Render(fmt.Sprintf("%d/%d files • %d marked • Tab: Mark • Enter: Run linters • Esc: Close",
+		len(f.matches), len(f.files), len(f.marked))))
+
+	return boxStyle.Width(max(f.width-4, 20)).Render(b.String())
+}
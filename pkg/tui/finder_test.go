@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFinderMarksAndRuns(t *testing.T) {
+	f := NewFinder()
+	f.Open("/repo", []string{"/repo/src/Controller/Home.php", "/repo/src/Model/User.php", "/repo/main.go"})
+
+	for _, r := range "user" {
+		f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if len(f.matches) != 1 || f.matches[0].Str != "src/Model/User.php" {
+		t.Fatalf("matches = %+v, want only src/Model/User.php", f.matches)
+	}
+
+	// Mark the match, then search again and mark another file
+	f.Update(tea.KeyMsg{Type: tea.KeyTab})
+	f.input.SetValue("main")
+	f.search()
+	f.Update(tea.KeyMsg{Type: tea.KeyTab})
+
+	cmd := f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if f.Active() {
+		t.Error("finder still active after enter")
+	}
+	if cmd == nil {
+		t.Fatal("enter returned no command")
+	}
+
+	msg, ok := cmd().(finderRunMsg)
+	if !ok {
+		t.Fatalf("enter produced %T, want finderRunMsg", cmd())
+	}
+	want := []string{"/repo/src/Model/User.php", "/repo/main.go"}
+	if !reflect.DeepEqual(msg.files, want) {
+		t.Errorf("files = %v, want %v", msg.files, want)
+	}
+}
@@ -0,0 +1,268 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tokenKind classifies a piece of highlighted source code
+type tokenKind int
+
+const (
+	tokenText tokenKind = iota
+	tokenKeyword
+	tokenString
+	tokenComment
+	tokenNumber
+	tokenVariable
+)
+
+// span is a run of source text of the same kind
+type span struct {
+	text string
+	kind tokenKind
+}
+
+// language describes the lexical syntax needed to highlight a language
+type language struct {
+	keywords map[string]bool
+
+	// lineComments start comments running to the end of the line
+	lineComments []string
+
+	// quotes open strings, rawQuotes open strings without escapes that may span lines
+	quotes    string
+	rawQuotes string
+
+	// variablePrefix marks variables, e.g. "$" in PHP
+	variablePrefix byte
+}
+
+// words builds a keyword set
+func words(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(s) {
+		set[word] = true
+	}
+	return set
+}
+
+var (
+	goLanguage = &language{
+		keywords: words(`break case chan const continue default defer else fallthrough for func go goto if
+			import interface map package range return select struct switch type var true false nil iota`),
+		lineComments: []string{"//"},
+		quotes:       `"'`,
+		rawQuotes:    "`",
+	}
+
+	phpLanguage = &language{
+		keywords: words(`abstract and array as break callable case catch class clone const continue declare
+			default do echo else elseif empty enddeclare endfor endforeach endif endswitch endwhile enum
+			extends final finally fn for foreach function global goto if implements include include_once
+			instanceof insteadof interface isset list match namespace new or print private protected public
+			readonly require require_once return static switch throw trait try unset use var while xor yield
+			true false null self parent TRUE FALSE NULL`),
+		lineComments:   []string{"//", "#"},
+		quotes:         `"'`,
+		variablePrefix: '$',
+	}
+
+	jsLanguage = &language{
+		keywords: words(`break case catch class const continue debugger default delete do else export extends
+			finally for function if import in instanceof let new return super switch this throw try typeof
+			var void while with yield async await of static get set from true false null undefined
+			interface type enum implements private protected public readonly abstract declare namespace as
+			any unknown never keyof`),
+		lineComments: []string{"//"},
+		quotes:       `"'`,
+		rawQuotes:    "`",
+	}
+)
+
+// languageFor returns the language of the file, or nil when it isn't highlighted
+func languageFor(path string) *language {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".go":
+		return goLanguage
+	case ".php", ".phtml":
+		return phpLanguage
+	case ".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx", ".mts", ".cts":
+		return jsLanguage
+	}
+	return nil
+}
+
+// highlightLines splits each line into spans. Block comments and raw strings
+// may continue over several lines.
+func highlightLines(lines []string, lang *language) [][]span {
+	result := make([][]span, len(lines))
+	if lang == nil {
+		for i, line := range lines {
+			result[i] = []span{{text: line, kind: tokenText}}
+		}
+		return result
+	}
+
+	var (
+		inComment bool
+		inString  byte
+	)
+	for n, line := range lines {
+		var spans []span
+		emit := func(text string, kind tokenKind) {
+			if text == "" {
+				return
+			}
+			if len(spans) > 0 && spans[len(spans)-1].kind == kind {
+				spans[len(spans)-1].text += text
+				return
+			}
+			spans = append(spans, span{text: text, kind: kind})
+		}
+
+		i := 0
+		for i < len(line) {
+			// Continue a block comment
+			if inComment {
+				end := strings.Index(line[i:], "*/")
+				if end < 0 {
+					emit(line[i:], tokenComment)
+					i = len(line)
+					break
+				}
+				emit(line[i:i+end+2], tokenComment)
+				i += end + 2
+				inComment = false
+				continue
+			}
+
+			// Continue a string
+			if inString != 0 {
+				j := i
+				for j < len(line) && line[j] != inString {
+					if line[j] == '\\' && !strings.ContainsRune(lang.rawQuotes, rune(inString)) {
+						j++
+					}
+					j++
+				}
+				if j >= len(line) {
+					emit(line[i:], tokenString)
+					i = len(line)
+					break
+				}
+				emit(line[i:j+1], tokenString)
+				i = j + 1
+				inString = 0
+				continue
+			}
+
+			c := line[i]
+			switch {
+			case strings.HasPrefix(line[i:], "/*"):
+				emit("/*", tokenComment)
+				i += 2
+				inComment = true
+			case hasAnyPrefix(line[i:], lang.lineComments):
+				emit(line[i:], tokenComment)
+				i = len(line)
+			case strings.IndexByte(lang.quotes+lang.rawQuotes, c) >= 0:
+				emit(line[i:i+1], tokenString)
+				i++
+				inString = c
+			case c >= '0' && c <= '9':
+				j := i
+				for j < len(line) && (isIdentChar(line[j]) || line[j] == '.') {
+					j++
+				}
+				emit(line[i:j], tokenNumber)
+				i = j
+			case lang.variablePrefix != 0 && c == lang.variablePrefix && i+1 < len(line) && isIdentStart(line[i+1]):
+				j := i + 1
+				for j < len(line) && isIdentChar(line[j]) {
+					j++
+				}
+				emit(line[i:j], tokenVariable)
+				i = j
+			case isIdentStart(c):
+				j := i
+				for j < len(line) && isIdentChar(line[j]) {
+					j++
+				}
+				if lang.keywords[line[i:j]] {
+					emit(line[i:j], tokenKeyword)
+				} else {
+					emit(line[i:j], tokenText)
+				}
+				i = j
+			default:
+				emit(line[i:i+1], tokenText)
+				i++
+			}
+		}
+
+		// Only raw strings continue on the next line
+		if inString != 0 && !strings.ContainsRune(lang.rawQuotes, rune(inString)) {
+			inString = 0
+		}
+		result[n] = spans
+	}
+	return result
+}
+
+// hasAnyPrefix reports whether s starts with one of the prefixes
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIdentStart reports whether c may start an identifier
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+// isIdentChar reports whether c may continue an identifier
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// tokenStyle returns the style of a token kind in the current theme
+func tokenStyle(kind tokenKind) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	switch kind {
+	case tokenKeyword:
+		return style.Foreground(primary).Bold(true)
+	case tokenString:
+		return style.Foreground(success)
+	case tokenComment:
+		return style.Foreground(muted).Italic(true)
+	case tokenNumber:
+		return style.Foreground(warningClr)
+	case tokenVariable:
+		return style.Foreground(info)
+	}
+	return style.Foreground(text)
+}
+
+// renderSpans renders the spans, cut off after width runes
+func renderSpans(spans []span, width int) string {
+	var b strings.Builder
+	for _, s := range spans {
+		if width <= 0 {
+			break
+		}
+		runes := []rune(s.text)
+		if len(runes) > width {
+			runes = runes[:width]
+		}
+		width -= len(runes)
+		b.WriteString(tokenStyle(s.kind).Render(string(runes)))
+	}
+	return b.String()
+}
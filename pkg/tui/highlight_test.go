@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crixuamg/pkg/linters"
+)
+
+func TestHighlightLines(t *testing.T) {
+	lines := []string{
+		`<?php $name = "a \" b"; // done`,
+		`/* start`,
+		`end */ return 42;`,
+	}
+
+	spans := highlightLines(lines, phpLanguage)
+
+	kinds := func(line []span) map[string]tokenKind {
+		result := make(map[string]tokenKind)
+		for _, s := range line {
+			result[s.text] = s.kind
+		}
+		return result
+	}
+
+	first := kinds(spans[0])
+	if first["$name"] != tokenVariable {
+		t.Errorf("$name highlighted as %v, want variable", first["$name"])
+	}
+	if first[`"a \" b"`] != tokenString {
+		t.Errorf("escaped string not highlighted as one string: %+v", spans[0])
+	}
+	if first["// done"] != tokenComment {
+		t.Errorf("line comment not highlighted: %+v", spans[0])
+	}
+
+	if len(spans[1]) != 1 || spans[1][0].kind != tokenComment {
+		t.Errorf("block comment start not highlighted: %+v", spans[1])
+	}
+
+	third := kinds(spans[2])
+	if third["end */"] != tokenComment || third["return"] != tokenKeyword || third["42"] != tokenNumber {
+		t.Errorf("block comment end not handled: %+v", spans[2])
+	}
+}
+
+func TestHighlightRawStringSpansLines(t *testing.T) {
+	spans := highlightLines([]string{"s := `first", "second` + x"}, goLanguage)
+
+	if spans[1][0].text != "second`" || spans[1][0].kind != tokenString {
+		t.Errorf("raw string not continued: %+v", spans[1])
+	}
+}
+
+func TestPreviewJumpToFinding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(path, []byte("package main\n\nfunc a() {}\n\nfunc b() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewPreview(path, []linters.Finding{
+		{Line: 3, Severity: linters.SeverityError},
+		{Line: 5, Severity: linters.SeverityWarning},
+	})
+	p.SetSize(40, 4)
+
+	p.jumpToFinding(false)
+	if p.cursor != 2 {
+		t.Errorf("cursor = %d after next finding, want 2", p.cursor)
+	}
+	p.jumpToFinding(false)
+	if p.cursor != 4 || p.offset != 3 {
+		t.Errorf("cursor = %d, offset = %d after next finding, want 4 and 3", p.cursor, p.offset)
+	}
+	p.jumpToFinding(true)
+	if p.cursor != 2 {
+		t.Errorf("cursor = %d after previous finding, want 2", p.cursor)
+	}
+}
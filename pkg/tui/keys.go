@@ -4,52 +4,42 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 )
 
-// keyMap defines the keybindings for the application
+// keyMap exposes the actions available on the active tab as key bindings
+// for the help view
 type keyMap struct {
-	Up    key.Binding
-	Down  key.Binding
-	Enter key.Binding
-	Back  key.Binding
-	Help  key.Binding
-	Quit  key.Binding
+	actions []Action
+}
+
+// bindings converts the actions to key bindings
+func (k keyMap) bindings() []key.Binding {
+	bindings := make([]key.Binding, 0, len(k.actions))
+	for _, action := range k.actions {
+		bindings = append(bindings, key.NewBinding(
+			key.WithKeys(action.Keys...),
+			key.WithHelp(action.KeyHelp(), action.Help),
+		))
+	}
+	return bindings
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Help, k.Quit}
+	return k.bindings()
 }
 
 // FullHelp returns keybindings for the expanded help view
 func (k keyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{
-		{k.Up, k.Down, k.Enter},
-		{k.Back, k.Help, k.Quit},
+	var columns [][]key.Binding
+	bindings := k.bindings()
+	for len(bindings) > 0 {
+		n := min(len(bindings), 4)
+		columns = append(columns, bindings[:n])
+		bindings = bindings[n:]
 	}
+	return columns
 }
 
-var keys = keyMap{
-	Up: key.NewBinding(
-		key.WithKeys("up", "k"),
-		key.WithHelp("↑/k", "move up"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("down", "j"),
-		key.WithHelp("↓/j", "move down"),
-	),
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "select"),
-	),
-	Back: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "back"),
-	),
-	Help: key.NewBinding(
-		key.WithKeys("?"),
-		key.WithHelp("?", "toggle help"),
-	),
-	Quit: key.NewBinding(
-		key.WithKeys("q", "ctrl+c"),
-		key.WithHelp("q", "quit"),
-	),
+// keyMap derives the key bindings of the active tab from the action registry
+func (m Model) keyMap() keyMap {
+	return keyMap{actions: m.visibleActions()}
 }
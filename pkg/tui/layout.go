@@ -0,0 +1,249 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/crixuamg/pkg/config"
+)
+
+// repoTab is the active tab while the repository pane is focused, which has
+// no tab of its own
+const repoTab = -2
+
+// paneTabs holds the tab focused by each pane of the panes layout, in order
+var paneTabs = []int{repoTab, 1, 0, 2}
+
+// Bounds of the split of the panes layout, in percent
+const (
+	minSplit = 20
+	maxSplit = 70
+)
+
+// repoPaneHeight is the height of the repository pane
+const repoPaneHeight = 11
+
+// clampSplit keeps the split within its bounds, using the default for unset values
+func clampSplit(split int) int {
+	if split == 0 {
+		return config.DefaultConfig().UI.Split
+	}
+	return min(max(split, minSplit), maxSplit)
+}
+
+// panesLayout reports whether the lazygit-style panes layout is used
+func (m Model) panesLayout() bool {
+	return m.layout == config.LayoutPanes
+}
+
+// leftWidth returns the width of the left column of the panes layout
+func (m Model) leftWidth() int {
+	return m.width * m.split / 100
+}
+
+// panesHeight returns the height of the panes, above the help and status bars
+func (m Model) panesHeight() int {
+	return m.height - lipgloss.Height(m.renderHelpBar()) - lipgloss.Height(m.renderStatusBar())
+}
+
+// resize sizes the explorer, the results and the panes for the window and
+// the layout
+func (m *Model) resize() {
+	if !m.panesLayout() {
+		m.resizeViewport()
+		m.explorer.SetSize(m.width, m.height)
+		return
+	}
+
+	leftWidth := m.leftWidth()
+	rightWidth := m.width - leftWidth
+	height := m.panesHeight()
+
+	if len(m.panes) >= 4 {
+		m.panes[0].SetSize(leftWidth, repoPaneHeight)
+		m.panes[1].SetSize(leftWidth, height-repoPaneHeight)
+		m.panes[2].SetSize(rightWidth, height)
+		m.panes[3].SetSize(rightWidth, height)
+	}
+}
+
+// syncPanes marks the pane of the active tab as active and remembers the tab
+// shown in the right column
+func (m *Model) syncPanes() {
+	switch m.activeTab {
+	case 0, 2, 3, 4, doctorTab:
+		m.rightTab = m.activeTab
+	}
+
+	m.activePaneIndex = -1
+	for i, tab := range paneTabs {
+		if tab == m.activeTab {
+			m.activePaneIndex = i
+		}
+	}
+
+	for i, pane := range m.panes {
+		pane.SetActive(i == m.activePaneIndex)
+		if tools, ok := pane.(*ToolsPane); ok {
+			tools.selected = m.selectedTool
+		}
+	}
+}
+
+// focusPane focuses the pane with the given index, refreshing the
+// repository pane when it gets the focus
+func (m *Model) focusPane(index int) tea.Cmd {
+	if index < 0 || index >= len(paneTabs) {
+		return nil
+	}
+	m.activeTab = paneTabs[index]
+	if m.activeTab == repoTab {
+		return loadRepoInfo(m.explorer.rootDir)
+	}
+	return nil
+}
+
+// cyclePanes moves the focus by delta panes
+func (m *Model) cyclePanes(delta int) tea.Cmd {
+	index := max(m.activePaneIndex, 0)
+	return m.focusPane((index + delta + len(paneTabs)) % len(paneTabs))
+}
+
+// toggleLayout switches between the tabs and the panes layout and stores
+// the choice in the user config
+func (m *Model) toggleLayout() tea.Cmd {
+	var cmds []tea.Cmd
+	if m.panesLayout() {
+		m.layout = config.LayoutTabs
+		if m.activeTab == repoTab {
+			m.activeTab = 0
+		}
+	} else {
+		m.layout = config.LayoutPanes
+		cmds = append(cmds, loadRepoInfo(m.explorer.rootDir))
+	}
+	m.config.UI.Layout = m.layout
+	m.resize()
+	return tea.Batch(append(cmds, saveSetting("ui.layout", m.layout))...)
+}
+
+// resizeSplit moves the split of the panes layout by delta percent and
+// stores it in the user config
+func (m *Model) resizeSplit(delta int) tea.Cmd {
+	m.setSplit(m.split + delta)
+	return saveSetting("ui.split", m.split)
+}
+
+// setSplit sets the split of the panes layout, in percent
+func (m *Model) setSplit(split int) {
+	m.split = clampSplit(split)
+	m.config.UI.Split = m.split
+	m.resize()
+}
+
+// saveSetting stores a setting in the user config
+func saveSetting(key string, value interface{}) tea.Cmd {
+	return func() tea.Msg {
+		if err := config.SaveUserSetting(key, value); err != nil {
+			return statusMsg{text: fmt.Sprintf("Failed to save %s: %s", key, err)}
+		}
+		return nil
+	}
+}
+
+// renderPanes renders the panes layout: the repository and the tools on the
+// left, the explorer, the results or another tab on the right
+func (m Model) renderPanes() string {
+	if len(m.panes) < 4 {
+		return ""
+	}
+
+	left := lipgloss.JoinVertical(lipgloss.Left, m.panes[0].View(), m.panes[1].View())
+
+	var right string
+	width, height := m.width-m.leftWidth(), m.panesHeight()
+	switch {
+	case m.palette.Active():
+		right = framePane("Commands", true, width, height, m.palette.View())
+	case m.finder.Active():
+		right = framePane("Find files", true, width, height, m.finder.View())
+	case m.rightTab == 2:
+		right = m.panes[3].View()
+	case m.rightTab == 3:
+		right = framePane("Config", m.activeTab == 3, width, height, m.renderConfigTab(width))
+	case m.rightTab == 4:
+		right = framePane("Trends", m.activeTab == 4, width, height, m.renderTrendsTab(width))
+	case m.rightTab == doctorTab:
+		right = framePane("Doctor", m.activeTab == doctorTab, width, height, m.renderDoctorTab(width))
+	default:
+		right = m.panes[2].View()
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+}
+
+// handlePanesMouse focuses clicked panes, drags the split and forwards clicks
+// and the wheel to the content of the panes
+func (m *Model) handlePanesMouse(msg tea.MouseMsg) tea.Cmd {
+	leftWidth := m.leftWidth()
+
+	// Drag the split by its border
+	switch {
+	case m.dragging && msg.Action == tea.MouseActionMotion:
+		if m.width > 0 {
+			m.setSplit(msg.X * 100 / m.width)
+		}
+		return nil
+	case m.dragging && msg.Action == tea.MouseActionRelease:
+		m.dragging = false
+		return saveSetting("ui.split", m.split)
+	case isClick(msg) && msg.X >= leftWidth-2 && msg.X <= leftWidth+1:
+		// Grab the borders of the panes or the margins between them
+		m.dragging = true
+		return nil
+	}
+
+	offsetX, offsetY := paneContentOffset()
+
+	// Left column
+	if msg.X < leftWidth {
+		if msg.Y < repoPaneHeight {
+			if isClick(msg) {
+				return m.focusPane(0)
+			}
+			return nil
+		}
+
+		switch delta := wheelDelta(msg); {
+		case delta < 0 && m.selectedTool > 0:
+			m.selectedTool--
+		case delta > 0 && m.selectedTool < len(m.activeLinters)-1:
+			m.selectedTool++
+		}
+		if isClick(msg) {
+			m.focusPane(1)
+			if row := msg.Y - repoPaneHeight - offsetY; row >= 0 && row < len(m.activeLinters) {
+				m.selectedTool = row
+			}
+		}
+		return nil
+	}
+
+	// Right column
+	if isClick(msg) {
+		m.activeTab = m.rightTab
+	}
+	x, y := msg.X-leftWidth-offsetX, msg.Y-offsetY
+	if x < 0 || y < 0 {
+		return nil
+	}
+
+	switch m.rightTab {
+	case 0:
+		return m.explorer.HandleMouse(msg, x, y)
+	case 2:
+		m.handleResultsMouse(msg, y)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/crixuamg/pkg/config"
+)
+
+func TestFramePaneFillsItsSize(t *testing.T) {
+	content := strings.Repeat("a line that is far too long for the pane\n", 30)
+
+	for _, active := range []bool{false, true} {
+		out := framePane("3: Explorer", active, 40, 12, content)
+		if w := lipgloss.Width(out); w != 40 {
+			t.Errorf("width = %d, want 40", w)
+		}
+		if h := lipgloss.Height(out); h != 12 {
+			t.Errorf("height = %d, want 12", h)
+		}
+		if !strings.Contains(out, "3: Explorer") {
+			t.Errorf("title missing from pane:\n%s", out)
+		}
+	}
+}
+
+func TestActionForKeyRespectsLayout(t *testing.T) {
+	m := Model{actions: defaultActions(nil, nil), layout: config.LayoutTabs, explorer: &Explorer{}}
+	if action := m.actionForKey("1"); action != nil {
+		t.Errorf("key 1 runs %q in the tabs layout", action.ID)
+	}
+
+	m.layout = config.LayoutPanes
+	action := m.actionForKey("1")
+	if action == nil || action.ID != "focus_pane_1" {
+		t.Fatalf("key 1 runs %v in the panes layout, want focus_pane_1", action)
+	}
+
+	action.Run(&m)
+	if m.activeTab != repoTab {
+		t.Errorf("activeTab = %d after focusing the repository pane, want %d", m.activeTab, repoTab)
+	}
+}
+
+func TestBindKeysAllowsSameKeyInOtherLayout(t *testing.T) {
+	actions := []Action{
+		{ID: "a", Keys: []string{"x"}, Tab: anyTab, Layout: config.LayoutPanes},
+		{ID: "b", Keys: []string{"x"}, Tab: anyTab, Layout: config.LayoutTabs},
+		{ID: "c", Keys: []string{"y"}, Tab: anyTab, Layout: config.LayoutPanes},
+		{ID: "d", Keys: []string{"y"}, Tab: 0},
+	}
+
+	err := bindKeys(actions, nil)
+	if err == nil {
+		t.Fatal("expected a conflict between c and d")
+	}
+	if strings.Contains(err.Error(), `"a"`) || !strings.Contains(err.Error(), `"c" and "d"`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCyclePanesFollowsPaneOrder(t *testing.T) {
+	m := Model{activeTab: 0, explorer: &Explorer{}}
+	m.syncPanes()
+
+	var tabs []int
+	for range paneTabs {
+		m.cyclePanes(1)
+		m.syncPanes()
+		tabs = append(tabs, m.activeTab)
+	}
+
+	want := []int{2, repoTab, 1, 0}
+	for i := range want {
+		if tabs[i] != want[i] {
+			t.Fatalf("tabs = %v, want %v", tabs, want)
+		}
+	}
+}
+
+func TestClampSplit(t *testing.T) {
+	testCases := map[int]int{0: 30, 5: minSplit, 45: 45, 90: maxSplit}
+	for split, want := range testCases {
+		if got := clampSplit(split); got != want {
+			t.Errorf("clampSplit(%d) = %d, want %d", split, got, want)
+		}
+	}
+}
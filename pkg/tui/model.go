@@ -2,7 +2,8 @@ package tui
 
 import (
 	"fmt"
-	"strings"
+	"os"
+	"path/filepath"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -10,6 +11,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/doctor"
+	"github.com/crixuamg/pkg/history"
 	"github.com/crixuamg/pkg/linters"
 )
 
@@ -32,8 +35,8 @@ func NewModel(cfg *config.Config, registry *linters.Registry) Model {
 	// Set the registry in the explorer
 	explorer.registry = registry
 
-	// Get available linters
-	activeLinters := registry.GetAvailable()
+	// Get the available linters enabled by the config and its active profile
+	activeLinters := registry.GetEnabled(cfg.LinterEnabled)
 
 	// Create tool names for the tools pane
 	var toolNames []string
@@ -46,38 +49,126 @@ func NewModel(cfg *config.Config, registry *linters.Registry) Model {
 	panes := []Pane{
 		NewRepoInfoPane(80, 8),
 		NewToolsPane(80, 16, toolNames),
-		NewExplorerPane(80, 24),
-		NewOutputPane(80, 24),
+		NewExplorerPane(80, 24, explorer),
+		NewOutputPane(80, 24, &vp),
 	}
 
-	return Model{
-		config:        cfg,
-		registry:      registry,
-		state:         StateMultiPane, // Start with the multi-pane layout as default
-		selectedTool:  0,
-		results:       make(map[string]*linters.Result),
-		viewport:      vp,
-		spinner:       s,
-		help:          help.New(),
-		keys:          keys,
-		explorer:      explorer,
-		activeLinters: activeLinters,
-		panes:         panes,
+	// Fall back to tabs for unknown layouts
+	layout := cfg.UI.Layout
+	if layout != config.LayoutPanes {
+		layout = config.LayoutTabs
+	}
+
+	// Build the action registry with the configured keys
+	actions, err := newActions(cfg, registry, activeLinters)
+	var status string
+	if err != nil {
+		status = err.Error()
+	}
+	explorer.focusKeys = keysFor(actions, "explorer_focus_preview")
+
+	m := Model{
+		config:          cfg,
+		status:          status,
+		registry:        registry,
+		state:           StateMultiPane, // Start with the multi-pane layout as default
+		selectedTool:    0,
+		results:         make(map[string]*linters.Result),
+		viewport:        &vp,
+		spinner:         s,
+		help:            help.New(),
+		explorer:        explorer,
+		finder:          NewFinder(),
+		palette:         NewPalette(),
+		actions:         actions,
+		configEditor:    NewConfigEditor(registryNames(registry)),
+		activeLinters:   activeLinters,
+		history:         history.NewStore(history.DefaultPath()),
+		layout:          layout,
+		split:           clampSplit(cfg.UI.Split),
+		panes:           panes,
 		activePaneIndex: 2, // Start with the file explorer pane active
-		activeTab:     0,   // Start with the Explorer tab
-		useNewUI:      true, // Always use the new UI
+		resultCursor:    -1,
+		activeTab:       0,    // Start with the Explorer tab
+		useNewUI:        true, // Always use the new UI
+	}
+	m.syncPanes()
+	return m
+}
+
+// SetTargets preselects the target files and directories in the explorer.
+// When run is true all available linters run on them on start.
+func (m *Model) SetTargets(targets []string, run bool) error {
+	var paths []string
+	for _, target := range targets {
+		path, err := filepath.Abs(target)
+		if err != nil {
+			return fmt.Errorf("invalid target %s: %w", target, err)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("target %s does not exist", target)
+		}
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	m.explorer.SelectTargets(paths)
+	m.targets = paths
+	m.runOnStart = run
+	return nil
+}
+
+// SetConfigPath sets the project config file the Config tab saves to
+func (m *Model) SetConfigPath(path string) {
+	m.configPath = path
+}
+
+// registryNames returns the names of all linters of the registry
+func registryNames(registry *linters.Registry) []string {
+	var names []string
+	for _, linter := range registry.GetAll() {
+		names = append(names, linter.Name())
 	}
+	return names
+}
+
+// SetStatus shows a message in the status bar, e.g. configuration warnings
+func (m *Model) SetStatus(text string) {
+	m.status = text
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.spinner.Tick,
-	)
+		m.loadHistory(),
+		m.runDoctor(),
+		loadRepoInfo(m.explorer.rootDir),
+		repoTick(),
+	}
+	if m.runOnStart {
+		cmds = append(cmds, func() tea.Msg { return startRunMsg{} })
+	}
+	if m.configChanges != nil {
+		cmds = append(cmds, waitForConfigChange(m.configChanges))
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update updates the model based on messages
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+
+	// Keep the panes in line with the active tab
+	next := model.(Model)
+	next.syncPanes()
+	return next, cmd
+}
+
+// update handles a message for Update
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var (
 		cmd  tea.Cmd
 		cmds []tea.Cmd
@@ -85,104 +176,45 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// Handle global keybindings first
-		switch msg.String() {
-		case "q":
-			// Quit the application
-			return m, tea.Quit
-
-		case "?":
-			// Toggle help
-			m.help.ShowAll = !m.help.ShowAll
-			return m, nil
-
-		case "tab":
-			// Next tab
-			m.activeTab = (m.activeTab + 1) % 4
-			return m, nil
-
-		case "shift+tab":
-			// Previous tab
-			m.activeTab = (m.activeTab - 1 + 4) % 4
-			return m, nil
+		// Overlays take all keys while open
+		if m.palette.Active() {
+			return m, m.palette.Update(msg)
+		}
+		if m.finder.Active() {
+			return m, m.finder.Update(msg)
+		}
 
-		case "t":
-			// Cycle through themes
-			var themes []string
-			for name := range Themes {
-				themes = append(themes, name)
-			}
+		// Let the explorer consume keys while typing a glob or filter
+		if m.activeTab == 0 && m.explorer.Capturing() {
+			var explorerCmd tea.Cmd
+			m.explorer, explorerCmd = m.explorer.Update(msg)
+			return m, explorerCmd
+		}
 
-			// Find current theme index
-			currentIndex := 0
-			for i, name := range themes {
-				if name == m.config.UI.Theme {
-					currentIndex = i
-					break
-				}
-			}
+		// Let the Config tab consume keys while typing a value or confirming a save
+		if m.activeTab == 3 && m.configEditor.Capturing() {
+			cmd := m.updateConfigEditor(msg)
+			return m, cmd
+		}
 
-			// Switch to next theme
-			nextIndex := (currentIndex + 1) % len(themes)
-			m.config.UI.Theme = themes[nextIndex]
-			ApplyTheme(m.config.UI.Theme)
-			return m, nil
+		// Run the action bound to the key
+		if action := m.actionForKey(msg.String()); action != nil {
+			cmd := action.Run(&m)
+			return m, cmd
 		}
 
-		// Handle tab-specific keybindings
+		// Leave navigation to the active tab
 		switch m.activeTab {
 		case 0: // Explorer tab
-			// Update explorer
 			var explorerCmd tea.Cmd
 			m.explorer, explorerCmd = m.explorer.Update(msg)
-
-			// Handle running tools on selected files
-			if msg.String() == "r" {
-				selectedFiles := m.explorer.GetSelectedFiles()
-				if len(selectedFiles) > 0 {
-					m.target = strings.Join(selectedFiles, " ")
-					m.state = StateRunning
-
-					// Run all available linters on the selected files
-					var cmds []tea.Cmd
-					for _, linter := range m.activeLinters {
-						cmds = append(cmds, m.runLinter(linter))
-					}
-					return m, tea.Batch(cmds...)
-				}
-			}
-
 			return m, explorerCmd
 
-		case 1: // Linters tab
-			// Handle navigation
-			switch msg.String() {
-			case "up", "k":
-				if m.selectedTool > 0 {
-					m.selectedTool--
-				}
-			case "down", "j":
-				if m.selectedTool < len(m.activeLinters)-1 {
-					m.selectedTool++
-				}
-			case "enter":
-				// Run selected linter
-				if m.selectedTool < len(m.activeLinters) {
-					m.state = StateRunning
-					return m, m.runLinter(m.activeLinters[m.selectedTool])
-				}
-			}
-			return m, nil
-
 		case 2: // Results tab
 			// Update viewport for scrolling
 			var cmd tea.Cmd
-			m.viewport, cmd = m.viewport.Update(msg)
+			*m.viewport, cmd = m.viewport.Update(msg)
 			return m, cmd
-
-		case 3: // Config tab
-			// No special handling needed yet
-			return m, nil
 		}
 
 		return m, nil
@@ -190,85 +222,121 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.viewport.Width = msg.Width - 4
-		m.viewport.Height = msg.Height - 10
 		m.help.Width = msg.Width
+		m.finder.SetSize(msg.Width, msg.Height-6)
+		m.palette.SetSize(msg.Width, msg.Height-6)
+		m.resize()
 
-		// Update explorer dimensions for the new UI
-		m.explorer.width = msg.Width - 10
-		m.explorer.height = msg.Height - 15
-		var cmd tea.Cmd
-		m.explorer, cmd = m.explorer.Update(msg)
-		cmds = append(cmds, cmd)
+	case watchTickMsg:
+		cmds = append(cmds, m.checkWatched(msg))
 
-		// Update pane dimensions for multi-pane layout (for backward compatibility)
-		// Left column (30% of width)
-		leftColWidth := msg.Width * 3 / 10
-		// Right column (70% of width)
-		rightColWidth := msg.Width - leftColWidth
+	case spinner.TickMsg:
+		if m.state == StateRunning {
+			m.spinner, cmd = m.spinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
 
-		// Reserve space for status and shortcut bars (2 lines)
-		contentHeight := msg.Height - 3 // Add extra space at the top
+	case statusMsg:
+		m.status = msg.text
 
-		// Top panes in left column
-		repoInfoHeight := 6 // Reduced height for repo info
-		toolsHeight := contentHeight - repoInfoHeight
+	case configChangedMsg:
+		m.reloadConfig(msg.path)
+		cmds = append(cmds, waitForConfigChange(m.configChanges))
 
-		// Set pane sizes
-		if len(m.panes) >= 4 {
-			// Repo info pane (top-left)
-			m.panes[0].SetSize(leftColWidth, repoInfoHeight)
+	case historyMsg:
+		// Errors of the history aren't linter jobs, they don't count as pending
+		if msg.err != "" {
+			m.err = msg.err
+		} else {
+			m.runs = msg.runs
+		}
 
-			// Tools pane (bottom-left)
-			m.panes[1].SetSize(leftColWidth, toolsHeight)
+	case doctorMsg:
+		m.doctorChecks = msg.checks
+		if doctor.Failed(msg.checks) && m.status == "" {
+			m.status = "Some enabled linters can't run, see the Doctor tab"
+		}
 
-			// Explorer pane (right, when active)
-			m.panes[2].SetSize(rightColWidth, contentHeight)
+	case repoInfoMsg:
+		for _, pane := range m.panes {
+			if repo, ok := pane.(*RepoInfoPane); ok {
+				repo.SetInfo(msg.root, msg.info, msg.toolchains)
+			}
+		}
 
-			// Output pane (right, when active)
-			m.panes[3].SetSize(rightColWidth, contentHeight)
+	case repoTickMsg:
+		// Only refresh the repository pane while it's shown
+		cmds = append(cmds, repoTick())
+		if m.panesLayout() {
+			cmds = append(cmds, loadRepoInfo(m.explorer.rootDir))
 		}
 
-	case spinner.TickMsg:
-		if m.state == StateRunning {
-			m.spinner, cmd = m.spinner.Update(msg)
-			cmds = append(cmds, cmd)
+	case paletteRunMsg:
+		if action := m.actionByID(msg.id); action != nil {
+			cmd := action.Run(&m)
+			return m, cmd
 		}
 
-	case linters.Result:
-		m.results[msg.Name] = &msg
+	case startRunMsg:
+		return m, m.startRun(m.activeLinters)
 
-		// Check if all expected linters have completed
-		expectedCount := 1
-		if m.selectedTool == len(m.activeLinters) { // File explorer mode with multiple files
-			expectedCount = len(m.activeLinters)
+	case finderRunMsg:
+		// Run all available linters on the files picked in the finder
+		m.targets = msg.files
+		return m, m.startRun(m.activeLinters)
+
+	case findingsFilterMsg:
+		m.resultsPath = msg.path
+		m.resultCursor = -1
+		m.updateViewportContent()
+		m.viewport.GotoTop()
+		m.activeTab = 2 // Switch to Results tab
+
+	case tea.MouseMsg:
+		// Overlays don't handle the mouse
+		if m.palette.Active() || m.finder.Active() {
+			return m, nil
+		}
+		cmd := m.handleMouse(msg)
+		return m, cmd
+
+	case errorMsg:
+		m.err = msg.err
+		if m.pending > 0 {
+			m.pending--
+			if m.pending == 0 {
+				m.state = StateResults
+				m.closeMirror()
+				m.explorer.SetFindings(m.resultList())
+				m.updateViewportContent()
+				if len(m.results) > 0 {
+					return m, m.recordRun()
+				}
+			}
 		}
 
-		if len(m.results) >= expectedCount {
+	case linters.Result:
+		m.results[msg.Label()] = &msg
+		if m.pending > 0 {
+			m.pending--
+		}
+
+		// Check if all expected linters have completed
+		if m.pending == 0 {
 			// All linters have completed, show results
 			m.state = StateResults
+			m.closeMirror()
+			m.explorer.SetFindings(m.resultList())
+			m.resultsPath = ""
 			m.activeTab = 2 // Switch to Results tab
 
-			// Combine results
-			var content strings.Builder
-			for _, result := range m.results {
-				content.WriteString(fmt.Sprintf("=== %s ===\n", result.Name))
-				content.WriteString(result.Output)
-				content.WriteString("\n\n")
-			}
-
 			// Set viewport content
-			m.viewport.SetContent(content.String())
+			m.resultCursor = -1
+			m.updateViewportContent()
 			m.viewport.GotoTop()
 
-			// Update output pane for backward compatibility
-			if len(m.panes) >= 4 {
-				outputPane, ok := m.panes[3].(*OutputPane)
-				if ok {
-					outputPane.SetContent(content.String())
-					outputPane.SetTitle("Linter Results")
-				}
-			}
+			// Store the completed run for the trends dashboard
+			cmds = append(cmds, m.recordRun())
 		}
 	}
 
@@ -277,5 +345,3 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 	return m, cmd
 }
-
-
@@ -0,0 +1,172 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// wheelLines is the number of lines scrolled per mouse wheel step
+const wheelLines = 3
+
+// isClick reports whether the mouse event is a left button press
+func isClick(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft
+}
+
+// wheelDelta returns -1 for wheel up, 1 for wheel down and 0 otherwise
+func wheelDelta(msg tea.MouseMsg) int {
+	if msg.Action != tea.MouseActionPress {
+		return 0
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return -1
+	case tea.MouseButtonWheelDown:
+		return 1
+	}
+	return 0
+}
+
+// handleMouse handles clicks on the tab headers and on the items of the
+// explorer and results, and scrolling with the wheel
+func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	if m.panesLayout() {
+		return m.handlePanesMouse(msg)
+	}
+
+	logoHeight := lipgloss.Height(renderLogo())
+	tabsHeight := lipgloss.Height(m.renderTabs())
+
+	// Switch tabs by clicking their headers
+	if msg.Y >= logoHeight && msg.Y < logoHeight+tabsHeight {
+		if tab := m.tabAt(msg.X); tab >= 0 && isClick(msg) {
+			m.activeTab = tab
+		}
+		return nil
+	}
+
+	// Position within the tab content, inside its border and padding
+	x := msg.X - tabContentStyle.GetBorderLeftSize() - tabContentStyle.GetPaddingLeft()
+	y := msg.Y - logoHeight - tabsHeight - tabContentStyle.GetBorderTopSize() - tabContentStyle.GetPaddingTop()
+	if x < 0 || y < 0 {
+		return nil
+	}
+
+	switch m.activeTab {
+	case 0: // Explorer tab
+		return m.explorer.HandleMouse(msg, x, y-lipgloss.Height(m.explorerHeader()))
+	case 2: // Results tab
+		m.handleResultsMouse(msg, y-lipgloss.Height(m.resultsHeader()))
+	}
+	return nil
+}
+
+// tabAt returns the index of the tab header at column x, or -1
+func (m Model) tabAt(x int) int {
+	left := 0
+	for i, name := range tabNames {
+		style := inactiveTabStyle
+		if i == m.activeTab {
+			style = activeTabStyle
+		}
+
+		width := lipgloss.Width(style.Render(name))
+		if x >= left && x < left+width-style.GetMarginRight() {
+			return i
+		}
+		left += width
+	}
+	return -1
+}
+
+// handleResultsMouse scrolls the results and opens clicked findings. y is
+// relative to the top of the viewport.
+func (m *Model) handleResultsMouse(msg tea.MouseMsg, y int) {
+	if delta := wheelDelta(msg); delta < 0 {
+		m.viewport.ScrollUp(wheelLines)
+		return
+	} else if delta > 0 {
+		m.viewport.ScrollDown(wheelLines)
+		return
+	}
+
+	if !isClick(msg) {
+		return
+	}
+	row := m.viewport.YOffset + y - m.viewport.Style.GetMarginTop()
+	if row < 0 || row >= len(m.resultRows) || m.resultRows[row] == nil {
+		return
+	}
+	m.selectResultRow(row)
+	m.openResult()
+}
+
+// HandleMouse selects and opens clicked items and scrolls the list and the
+// preview with the wheel. x and y are relative to the top left of the explorer.
+func (e *Explorer) HandleMouse(msg tea.MouseMsg, x, y int) tea.Cmd {
+	if e.prompting {
+		y -= lipgloss.Height(e.prompt.View())
+	}
+
+	// The list takes the left side
+	listWidth := lipgloss.Width(e.list.View())
+	if x < listWidth {
+		switch wheelDelta(msg) {
+		case -1:
+			e.list.CursorUp()
+		case 1:
+			e.list.CursorDown()
+		}
+
+		if isClick(msg) {
+			// Clicking the selected item opens it
+			if index, ok := e.itemAt(y); ok {
+				if index == e.list.Index() {
+					e.OpenSelected()
+				} else {
+					e.list.Select(index)
+				}
+			}
+		}
+		return nil
+	}
+
+	// The preview takes the right side
+	if e.preview == nil || !e.showPreview {
+		return nil
+	}
+	if delta := wheelDelta(msg); delta != 0 {
+		e.preview.moveCursor(delta * wheelLines)
+		return nil
+	}
+	if isClick(msg) {
+		// Move the cursor to the clicked line to show its findings
+		top := previewStyle.GetMarginTop() + previewStyle.GetBorderTopSize() + previewStyle.GetPaddingTop()
+		if line := e.preview.offset + y - top; y >= top && line < e.preview.offset+e.preview.bodyHeight() {
+			e.preview.moveCursor(line - e.preview.cursor)
+		}
+		e.previewFocus = true
+	}
+	return nil
+}
+
+// itemAt returns the index of the list item at row y of the list
+func (e *Explorer) itemAt(y int) (int, bool) {
+	titleHeight := lipgloss.Height(e.list.Styles.TitleBar.Render(e.list.Styles.Title.Render(e.list.Title)))
+	row := y - titleHeight
+	if row < 0 || e.itemHeight <= 0 {
+		return 0, false
+	}
+
+	paginator := e.list.Paginator
+	index := row / e.itemHeight
+	if index >= paginator.PerPage {
+		return 0, false
+	}
+
+	index += paginator.Page * paginator.PerPage
+	if index >= len(e.list.VisibleItems()) {
+		return 0, false
+	}
+	return index, true
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/linters"
+)
+
+func TestTabAt(t *testing.T) {
+	m := Model{activeTab: 1}
+
+	left := 0
+	for i, name := range tabNames {
+		style := inactiveTabStyle
+		if i == m.activeTab {
+			style = activeTabStyle
+		}
+		width := lipgloss.Width(style.Render(name))
+
+		if got := m.tabAt(left); got != i {
+			t.Errorf("tabAt(%d) = %d, want %d", left, got, i)
+		}
+		if got := m.tabAt(left + width - 1); got != -1 {
+			t.Errorf("tabAt(%d) = %d on the margin, want -1", left+width-1, got)
+		}
+		left += width
+	}
+}
+
+func TestClickFindingOpensPreview(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(path, []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(config.DefaultConfig(), linters.DefaultRegistry())
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+
+	m.results = map[string]*linters.Result{
+		"golangci-lint": {Name: "golangci-lint", Output: path + ":3:1: unused function (unused)\n"},
+	}
+	m.activeTab = 2
+	m.updateViewportContent()
+
+	// The finding follows the linter header, the status line and a blank line
+	row := 3
+	if m.resultRows[row] == nil {
+		t.Fatalf("row %d has no finding: %v", row, m.resultRows)
+	}
+
+	top := lipgloss.Height(renderLogo()) + lipgloss.Height(m.renderTabs()) + tabContentStyle.GetPaddingTop() +
+		lipgloss.Height(titleStyle.Render("Linter Results")) + m.viewport.Style.GetMarginTop()
+	m.handleMouse(tea.MouseMsg{X: 10, Y: top + row, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+
+	if m.activeTab != 0 {
+		t.Errorf("activeTab = %d after clicking a finding, want the explorer", m.activeTab)
+	}
+	if m.explorer.preview == nil || m.explorer.preview.path != path || m.explorer.preview.cursor != 2 {
+		t.Errorf("preview = %+v, want %s at line 3", m.explorer.preview, path)
+	}
+}
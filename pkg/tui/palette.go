@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// paletteRunMsg asks to run the action picked in the command palette
+type paletteRunMsg struct {
+	id string
+}
+
+// Palette is an overlay that fuzzy-searches all actions
+type Palette struct {
+	input   textinput.Model
+	actions []Action
+	matches fuzzy.Matches
+	cursor  int
+	active  bool
+	width   int
+	height  int
+}
+
+// NewPalette creates a closed command palette
+func NewPalette() *Palette {
+	input := textinput.New()
+	input.Prompt = ": "
+	input.Placeholder = "Type a command"
+	input.PromptStyle = infoStyle
+
+	return &Palette{input: input}
+}
+
+// Open shows the palette for the given actions
+func (p *Palette) Open(actions []Action) tea.Cmd {
+	p.actions = actions
+	p.active = true
+	p.cursor = 0
+	p.input.SetValue("")
+	p.search()
+	return p.input.Focus()
+}
+
+// Close hides the palette
+func (p *Palette) Close() {
+	p.active = false
+	p.input.Blur()
+}
+
+// Active reports whether the palette is shown
+func (p *Palette) Active() bool {
+	return p.active
+}
+
+// SetSize sets the size of the overlay
+func (p *Palette) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// search updates the matches for the current query. An empty query lists all actions.
+func (p *Palette) search() {
+	titles := make([]string, len(p.actions))
+	for i, action := range p.actions {
+		titles[i] = action.Title
+	}
+
+	query := p.input.Value()
+	if query == "" {
+		p.matches = make(fuzzy.Matches, len(titles))
+		for i, title := range titles {
+			p.matches[i] = fuzzy.Match{Str: title, Index: i}
+		}
+	} else {
+		p.matches = fuzzy.Find(query, titles)
+	}
+	p.cursor = max(0, min(p.cursor, len(p.matches)-1))
+}
+
+// Update handles key presses while the palette is open
+func (p *Palette) Update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		p.Close()
+		return nil
+	case "up", "ctrl+p":
+		p.cursor = max(p.cursor-1, 0)
+		return nil
+	case "down", "ctrl+n":
+		p.cursor = min(p.cursor+1, max(len(p.matches)-1, 0))
+		return nil
+	case "enter":
+		p.Close()
+		if p.cursor >= len(p.matches) {
+			return nil
+		}
+		id := p.actions[p.matches[p.cursor].Index].ID
+		return func() tea.Msg { return paletteRunMsg{id: id} }
+	}
+
+	var cmd tea.Cmd
+	previous := p.input.Value()
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != previous {
+		p.cursor = 0
+		p.search()
+	}
+	return cmd
+}
+
+// View renders the palette with the shortcut of each action
+func (p *Palette) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Commands"))
+	b.WriteString("\n")
+	b.WriteString(p.input.View())
+	b.WriteString("\n\n")
+
+	// Keep the cursor within the visible rows
+	rows := max(p.height-8, 1)
+	start := max(0, p.cursor-rows+1)
+	end := min(start+rows, len(p.matches))
+
+	if len(p.matches) == 0 {
+		b.WriteString(infoStyle.Render("No matching commands"))
+		b.WriteString("\n")
+	}
+
+	width := max(p.width-10, 20)
+	shortcutStyle := lipgloss.NewStyle().Foreground(muted)
+	for i := start; i < end; i++ {
+		match := p.matches[i]
+		action := p.actions[match.Index]
+
+		// Show the shortcut right-aligned, with the tab it works on
+		shortcut := action.KeyHelp()
+		if shortcut != "" && action.Tab != anyTab {
+			shortcut += " (" + tabNames[action.Tab] + ")"
+		}
+
+		title := highlightMatch(match)
+		gap := max(width-lipgloss.Width(title)-lipgloss.Width(shortcut)-2, 1)
+		line := title + strings.Repeat(" ", gap) + shortcutStyle.Render(shortcut)
+		if i == p.cursor {
+			line = selectedItemStyle.Render("> ") + line
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(infoStyle.Render("Enter: Run • Esc: Close"))
+
+	return boxStyle.Width(max(p.width-4, 20)).Render(b.String())
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/crixuamg/pkg/git"
 )
 
 // PaneType represents the type of pane
@@ -32,11 +33,16 @@ type Pane interface {
 
 // RepoInfoPane displays information about the repository
 type RepoInfoPane struct {
-	width  int
-	height int
+	width    int
+	height   int
 	repoName string
 	repoPath string
 	isActive bool
+
+	// Refreshed by repoInfoMsg, info is nil outside a git repository
+	loaded     bool
+	info       *git.RepoInfo
+	toolchains []string
 }
 
 // NewRepoInfoPane creates a new repo info pane
@@ -53,8 +59,8 @@ func NewRepoInfoPane(width, height int) *RepoInfoPane {
 	repoName := filepath.Base(repoPath)
 
 	return &RepoInfoPane{
-		width:  width,
-		height: height,
+		width:    width,
+		height:   height,
 		repoName: repoName,
 		repoPath: repoPath,
 	}
@@ -67,54 +73,86 @@ func (p *RepoInfoPane) Update(msg tea.Msg) (Pane, tea.Cmd) {
 
 // View renders the repo info pane
 func (p *RepoInfoPane) View() string {
-	// Use the appropriate style based on whether this pane is active
-	var style lipgloss.Style
-	if p.isActive {
-		style = activePaneStyle.Copy()
-	} else {
-		style = inactivePaneStyle.Copy()
-	}
+	lines := []string{subtitleStyle.Render(p.repoName) + " " + p.branchLine()}
+	lines = append(lines, infoStyle.Render(p.repoPath))
 
-	style = style.Width(p.width - 4).
-		Height(p.height - 2)
+	if p.info != nil {
+		lines = append(lines, p.changesLine())
+		if p.info.Head != "" {
+			lines = append(lines, infoStyle.Render(p.info.Head)+" "+p.info.Subject)
+		} else {
+			lines = append(lines, infoStyle.Render("No commits yet"))
+		}
+	}
 
-	// Add title to the border
-	style = style.Border(lipgloss.RoundedBorder())
+	if len(p.toolchains) > 0 {
+		lines = append(lines, strings.Join(p.toolchains, ", "))
+	} else if p.loaded {
+		lines = append(lines, infoStyle.Render("No composer.json, go.mod or package.json"))
+	}
 
-	// Add title to the top border with pane index
-	title := "1: Repository"
+	return framePane("1: Repository", p.isActive, p.width, p.height, strings.Join(lines, "\n"))
+}
 
-	content := fmt.Sprintf("Repository: %s\nPath: %s", p.repoName, p.repoPath)
-	renderedContent := style.Render(content)
+// branchLine describes the branch and how it relates to its upstream
+func (p *RepoInfoPane) branchLine() string {
+	switch {
+	case !p.loaded:
+		return ""
+	case p.info == nil:
+		return warningStyle.Render("not a git repository")
+	}
 
-	// Add title to the top border
-	lines := strings.Split(renderedContent, "\n")
-	if len(lines) > 0 {
-		// Find the position to insert the title
-		firstLine := lines[0]
+	branch := p.info.Branch
+	if branch == "" {
+		branch = "detached at " + p.info.Head
+	}
+	line := selectedItemStyle.Render(branch)
 
-		// Ensure title doesn't exceed pane width
-		maxTitleLen := len(firstLine) - 4 // Leave some space for borders
-		if len(title) > maxTitleLen && maxTitleLen > 5 {
-			// Truncate title if it's too long
-			title = title[:maxTitleLen-3] + "..."
+	if p.info.Upstream != "" {
+		line += " → " + p.info.Upstream
+		if p.info.Ahead == 0 && p.info.Behind == 0 {
+			line += " " + successStyle.Render("✓")
 		}
-
-		titlePos := (len(firstLine) - len(title)) / 2
-		if titlePos < 0 {
-			titlePos = 0
+		if p.info.Ahead > 0 {
+			line += " " + warningStyle.Render(fmt.Sprintf("↑%d", p.info.Ahead))
 		}
-
-		// Insert the title
-		if titlePos+len(title) <= len(firstLine) {
-			lines[0] = firstLine[:titlePos] + title + firstLine[titlePos+len(title):]
+		if p.info.Behind > 0 {
+			line += " " + warningStyle.Render(fmt.Sprintf("↓%d", p.info.Behind))
 		}
+	}
+	return line
+}
 
-		// Rejoin the lines
-		return strings.Join(lines, "\n")
+// changesLine counts the changed files of the working tree
+func (p *RepoInfoPane) changesLine() string {
+	if !p.info.Dirty() {
+		return successStyle.Render("clean")
 	}
 
-	return renderedContent
+	var parts []string
+	if p.info.Staged > 0 {
+		parts = append(parts, successStyle.Render(fmt.Sprintf("%d staged", p.info.Staged)))
+	}
+	if p.info.Unstaged > 0 {
+		parts = append(parts, warningStyle.Render(fmt.Sprintf("%d modified", p.info.Unstaged)))
+	}
+	if p.info.Untracked > 0 {
+		parts = append(parts, infoStyle.Render(fmt.Sprintf("%d untracked", p.info.Untracked)))
+	}
+	if p.info.Conflicted > 0 {
+		parts = append(parts, errorStyle.Render(fmt.Sprintf("%d conflicted", p.info.Conflicted)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// SetInfo updates the repository, its git status and toolchains shown in the pane
+func (p *RepoInfoPane) SetInfo(root string, info *git.RepoInfo, toolchains []string) {
+	p.repoName = filepath.Base(root)
+	p.repoPath = root
+	p.loaded = true
+	p.info = info
+	p.toolchains = toolchains
 }
 
 // SetActive sets whether this pane is active
@@ -135,9 +173,9 @@ func (p *RepoInfoPane) GetType() PaneType {
 
 // ToolsPane displays available tools
 type ToolsPane struct {
-	width  int
-	height int
-	tools  []string
+	width    int
+	height   int
+	tools    []string
 	selected int
 	isActive bool
 }
@@ -145,13 +183,19 @@ type ToolsPane struct {
 // NewToolsPane creates a new tools pane
 func NewToolsPane(width, height int, tools []string) *ToolsPane {
 	return &ToolsPane{
-		width:  width,
-		height: height,
-		tools:  tools,
+		width:    width,
+		height:   height,
+		tools:    tools,
 		selected: 0,
 	}
 }
 
+// SetTools replaces the listed tools
+func (p *ToolsPane) SetTools(tools []string) {
+	p.tools = tools
+	p.selected = max(0, min(p.selected, len(tools)-1))
+}
+
 // Update updates the tools pane
 func (p *ToolsPane) Update(msg tea.Msg) (Pane, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -172,23 +216,6 @@ func (p *ToolsPane) Update(msg tea.Msg) (Pane, tea.Cmd) {
 
 // View renders the tools pane
 func (p *ToolsPane) View() string {
-	// Use the appropriate style based on whether this pane is active
-	var style lipgloss.Style
-	if p.isActive {
-		style = activePaneStyle.Copy()
-	} else {
-		style = inactivePaneStyle.Copy()
-	}
-
-	style = style.Width(p.width - 4).
-		Height(p.height - 2)
-
-	// Add title to the border
-	style = style.Border(lipgloss.RoundedBorder())
-
-	// Add title to the top border with pane index
-	title := "2: Tools"
-
 	var content strings.Builder
 
 	for i, tool := range p.tools {
@@ -200,36 +227,11 @@ func (p *ToolsPane) View() string {
 		content.WriteString("\n")
 	}
 
-	renderedContent := style.Render(content.String())
-
-	// Add title to the top border
-	lines := strings.Split(renderedContent, "\n")
-	if len(lines) > 0 {
-		// Find the position to insert the title
-		firstLine := lines[0]
-
-		// Ensure title doesn't exceed pane width
-		maxTitleLen := len(firstLine) - 4 // Leave some space for borders
-		if len(title) > maxTitleLen && maxTitleLen > 5 {
-			// Truncate title if it's too long
-			title = title[:maxTitleLen-3] + "..."
-		}
-
-		titlePos := (len(firstLine) - len(title)) / 2
-		if titlePos < 0 {
-			titlePos = 0
-		}
-
-		// Insert the title
-		if titlePos+len(title) <= len(firstLine) {
-			lines[0] = firstLine[:titlePos] + title + firstLine[titlePos+len(title):]
-		}
-
-		// Rejoin the lines
-		return strings.Join(lines, "\n")
+	if len(p.tools) == 0 {
+		content.WriteString(infoStyle.Render("No linters available"))
 	}
 
-	return renderedContent
+	return framePane("2: Tools", p.isActive, p.width, p.height, content.String())
 }
 
 // SetActive sets whether this pane is active
@@ -256,86 +258,38 @@ func (p *ToolsPane) GetSelectedTool() string {
 	return ""
 }
 
-// OutputPane displays the output of a command
+// OutputPane displays the linter results in the viewport shared with the
+// results tab
 type OutputPane struct {
 	width    int
 	height   int
-	viewport viewport.Model
-	content  string
-	title    string
+	viewport *viewport.Model
 	isActive bool
 }
 
-// NewOutputPane creates a new output pane
-func NewOutputPane(width, height int) *OutputPane {
-	vp := viewport.New(width-4, height-4)
-	vp.Style = lipgloss.NewStyle()
-
+// NewOutputPane creates a new output pane showing the given viewport
+func NewOutputPane(width, height int, vp *viewport.Model) *OutputPane {
 	return &OutputPane{
 		width:    width,
 		height:   height,
 		viewport: vp,
-		content:  "No output to display",
-		title:    "Output",
 	}
 }
 
 // Update updates the output pane
 func (p *OutputPane) Update(msg tea.Msg) (Pane, tea.Cmd) {
 	var cmd tea.Cmd
-	p.viewport, cmd = p.viewport.Update(msg)
+	*p.viewport, cmd = p.viewport.Update(msg)
 	return p, cmd
 }
 
 // View renders the output pane
 func (p *OutputPane) View() string {
-	// Use the appropriate style based on whether this pane is active
-	var style lipgloss.Style
-	if p.isActive {
-		style = activePaneStyle.Copy()
-	} else {
-		style = inactivePaneStyle.Copy()
+	content := p.viewport.View()
+	if p.viewport.TotalLineCount() == 0 {
+		content = infoStyle.Render("No results yet. Run a linter to see results here.")
 	}
-
-	style = style.Width(p.width - 4).
-		Height(p.height - 2)
-
-	// Add title to the border
-	style = style.Border(lipgloss.RoundedBorder())
-
-	// Add title to the top border with pane index
-	title := "4: Output"
-
-	renderedContent := style.Render(p.viewport.View())
-
-	// Add title to the top border
-	lines := strings.Split(renderedContent, "\n")
-	if len(lines) > 0 {
-		// Find the position to insert the title
-		firstLine := lines[0]
-
-		// Ensure title doesn't exceed pane width
-		maxTitleLen := len(firstLine) - 4 // Leave some space for borders
-		if len(title) > maxTitleLen && maxTitleLen > 5 {
-			// Truncate title if it's too long
-			title = title[:maxTitleLen-3] + "..."
-		}
-
-		titlePos := (len(firstLine) - len(title)) / 2
-		if titlePos < 0 {
-			titlePos = 0
-		}
-
-		// Insert the title
-		if titlePos+len(title) <= len(firstLine) {
-			lines[0] = firstLine[:titlePos] + title + firstLine[titlePos+len(title):]
-		}
-
-		// Rejoin the lines
-		return strings.Join(lines, "\n")
-	}
-
-	return renderedContent
+	return framePane("4: Output", p.isActive, p.width, p.height, content)
 }
 
 // SetActive sets whether this pane is active
@@ -347,8 +301,7 @@ func (p *OutputPane) SetActive(active bool) {
 func (p *OutputPane) SetSize(width, height int) {
 	p.width = width
 	p.height = height
-	p.viewport.Width = width - 8
-	p.viewport.Height = height - 8
+	p.viewport.Width, p.viewport.Height = paneContentSize(width, height)
 }
 
 // GetType returns the type of pane
@@ -356,17 +309,6 @@ func (p *OutputPane) GetType() PaneType {
 	return PaneOutput
 }
 
-// SetContent sets the content of the output pane
-func (p *OutputPane) SetContent(content string) {
-	p.content = content
-	p.viewport.SetContent(content)
-}
-
-// SetTitle sets the title of the output pane
-func (p *OutputPane) SetTitle(title string) {
-	p.title = title
-}
-
 // ExplorerPane is a wrapper around the Explorer
 type ExplorerPane struct {
 	width    int
@@ -375,12 +317,12 @@ type ExplorerPane struct {
 	isActive bool
 }
 
-// NewExplorerPane creates a new explorer pane
-func NewExplorerPane(width, height int) *ExplorerPane {
+// NewExplorerPane creates a new explorer pane showing the given explorer
+func NewExplorerPane(width, height int, explorer *Explorer) *ExplorerPane {
 	return &ExplorerPane{
 		width:    width,
 		height:   height,
-		explorer: NewExplorer(width, height),
+		explorer: explorer,
 	}
 }
 
@@ -393,53 +335,7 @@ func (p *ExplorerPane) Update(msg tea.Msg) (Pane, tea.Cmd) {
 
 // View renders the explorer pane
 func (p *ExplorerPane) View() string {
-	// Use the appropriate style based on whether this pane is active
-	var style lipgloss.Style
-	if p.isActive {
-		style = activePaneStyle.Copy()
-	} else {
-		style = inactivePaneStyle.Copy()
-	}
-
-	style = style.Width(p.width - 4).
-		Height(p.height - 2)
-
-	// Add title to the border
-	style = style.Border(lipgloss.RoundedBorder())
-
-	// Add title to the top border with pane index
-	title := "3: Explorer"
-
-	renderedContent := style.Render(p.explorer.View())
-
-	// Add title to the top border
-	lines := strings.Split(renderedContent, "\n")
-	if len(lines) > 0 {
-		// Find the position to insert the title
-		firstLine := lines[0]
-
-		// Ensure title doesn't exceed pane width
-		maxTitleLen := len(firstLine) - 4 // Leave some space for borders
-		if len(title) > maxTitleLen && maxTitleLen > 5 {
-			// Truncate title if it's too long
-			title = title[:maxTitleLen-3] + "..."
-		}
-
-		titlePos := (len(firstLine) - len(title)) / 2
-		if titlePos < 0 {
-			titlePos = 0
-		}
-
-		// Insert the title
-		if titlePos+len(title) <= len(firstLine) {
-			lines[0] = firstLine[:titlePos] + title + firstLine[titlePos+len(title):]
-		}
-
-		// Rejoin the lines
-		return strings.Join(lines, "\n")
-	}
-
-	return renderedContent
+	return framePane("3: Explorer", p.isActive, p.width, p.height, p.explorer.View())
 }
 
 // SetActive sets whether this pane is active
@@ -451,9 +347,7 @@ func (p *ExplorerPane) SetActive(active bool) {
 func (p *ExplorerPane) SetSize(width, height int) {
 	p.width = width
 	p.height = height
-	p.explorer.width = width - 8
-	p.explorer.height = height - 8
-	p.explorer.list.SetSize(width/2 - 10, height - 12)
+	p.explorer.SetSize(paneContentSize(width, height))
 }
 
 // GetType returns the type of pane
@@ -470,3 +364,50 @@ func (p *ExplorerPane) GetSelectedFiles() []string {
 func (p *ExplorerPane) GetExplorer() *Explorer {
 	return p.explorer
 }
+
+// paneContentSize returns the space left for the content of a pane of the
+// given size, inside its margin, border and padding
+func paneContentSize(width, height int) (int, int) {
+	return max(width-inactivePaneStyle.GetHorizontalFrameSize(), 1),
+		max(height-inactivePaneStyle.GetVerticalFrameSize(), 1)
+}
+
+// paneContentOffset returns the position of the content within a pane
+func paneContentOffset() (int, int) {
+	style := inactivePaneStyle
+	return style.GetMarginLeft() + style.GetBorderLeftSize() + style.GetPaddingLeft(),
+		style.GetMarginTop() + style.GetBorderTopSize() + style.GetPaddingTop()
+}
+
+// framePane renders content in a pane of exactly the given size, with the
+// title in its top border. Content that doesn't fit is cut off.
+func framePane(title string, active bool, width, height int, content string) string {
+	style := inactivePaneStyle
+	if active {
+		style = activePaneStyle
+	}
+
+	contentWidth, contentHeight := paneContentSize(width, height)
+	content = lipgloss.NewStyle().MaxWidth(contentWidth).MaxHeight(contentHeight).Render(content)
+
+	body := style.UnsetMargins().
+		BorderTop(false).
+		Width(contentWidth + style.GetHorizontalPadding()).
+		Height(contentHeight + style.GetVerticalPadding()).
+		Render(content)
+
+	// Draw the top border with the title in it
+	border := lipgloss.RoundedBorder()
+	edge := lipgloss.Width(body) - 2
+	label := " " + title + " "
+	if lipgloss.Width(label)+1 > edge {
+		label = ""
+	}
+	top := border.TopLeft + border.Top + label +
+		strings.Repeat(border.Top, max(edge-1-lipgloss.Width(label), 0)) + border.TopRight
+	top = lipgloss.NewStyle().Foreground(style.GetBorderTopForeground()).Bold(active).Render(top)
+
+	return lipgloss.NewStyle().
+		Margin(style.GetMargin()).
+		Render(lipgloss.JoinVertical(lipgloss.Left, top, body))
+}
@@ -0,0 +1,197 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/crixuamg/pkg/linters"
+)
+
+// Preview shows a file with line numbers, syntax highlighting and markers
+// on the lines that have findings
+type Preview struct {
+	path     string
+	lines    []string
+	spans    [][]span
+	findings map[int][]linters.Finding
+	err      error
+
+	// cursor is the current line and offset the first visible line, both zero-based
+	cursor int
+	offset int
+
+	width  int
+	height int
+}
+
+// NewPreview loads the file at path
+func NewPreview(path string, findings []linters.Finding) *Preview {
+	p := &Preview{path: path}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		p.err = err
+		return p
+	}
+
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\t", "    ")
+	p.lines = strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	p.spans = highlightLines(p.lines, languageFor(path))
+	p.SetFindings(findings)
+	return p
+}
+
+// SetFindings replaces the findings shown in the gutter
+func (p *Preview) SetFindings(findings []linters.Finding) {
+	p.findings = make(map[int][]linters.Finding)
+	for _, finding := range findings {
+		if finding.Line > 0 {
+			p.findings[finding.Line] = append(p.findings[finding.Line], finding)
+		}
+	}
+}
+
+// SetSize sets the size of the preview content
+func (p *Preview) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+	p.scrollToCursor()
+}
+
+// bodyHeight is the number of source lines shown above the footer
+func (p *Preview) bodyHeight() int {
+	return max(p.height-2, 1)
+}
+
+// moveCursor moves the cursor by delta lines
+func (p *Preview) moveCursor(delta int) {
+	p.cursor = max(0, min(p.cursor+delta, len(p.lines)-1))
+	p.scrollToCursor()
+}
+
+// scrollToCursor keeps the cursor line visible
+func (p *Preview) scrollToCursor() {
+	height := p.bodyHeight()
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+height {
+		p.offset = p.cursor - height + 1
+	}
+	p.offset = max(0, min(p.offset, len(p.lines)-height))
+}
+
+// findingLines returns the lines with findings in ascending order
+func (p *Preview) findingLines() []int {
+	lines := make([]int, 0, len(p.findings))
+	for line := range p.findings {
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+	return lines
+}
+
+// jumpToFinding moves the cursor to the next finding, or the previous one when backwards
+func (p *Preview) jumpToFinding(backwards bool) {
+	lines := p.findingLines()
+	if backwards {
+		for i := len(lines) - 1; i >= 0; i-- {
+			if lines[i]-1 < p.cursor {
+				p.moveCursor(lines[i] - 1 - p.cursor)
+				return
+			}
+		}
+		return
+	}
+	for _, line := range lines {
+		if line-1 > p.cursor {
+			p.moveCursor(line - 1 - p.cursor)
+			return
+		}
+	}
+}
+
+// Update scrolls the preview
+func (p *Preview) Update(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "up", "k":
+		p.moveCursor(-1)
+	case "down", "j":
+		p.moveCursor(1)
+	case "pgup", "ctrl+u":
+		p.moveCursor(-p.bodyHeight())
+	case "pgdown", "ctrl+d":
+		p.moveCursor(p.bodyHeight())
+	case "home", "g":
+		p.moveCursor(-len(p.lines))
+	case "end", "G":
+		p.moveCursor(len(p.lines))
+	case "n":
+		p.jumpToFinding(false)
+	case "N":
+		p.jumpToFinding(true)
+	}
+}
+
+// View renders the visible lines and a footer with the findings on the cursor line
+func (p *Preview) View(focused bool) string {
+	if p.err != nil {
+		return fmt.Sprintf("Error loading file: %s", p.err)
+	}
+
+	numberWidth := len(fmt.Sprint(len(p.lines)))
+	codeWidth := max(p.width-numberWidth-3, 1)
+	numberStyle := lipgloss.NewStyle().Foreground(muted)
+	cursorStyle := lipgloss.NewStyle().Foreground(primary).Bold(true)
+
+	var b strings.Builder
+	end := min(p.offset+p.bodyHeight(), len(p.lines))
+	for i := p.offset; i < end; i++ {
+		// Gutter marker colored by the most severe finding of the line
+		marker := " "
+		if findings := p.findings[i+1]; len(findings) > 0 {
+			marker = warningStyle.Render("●")
+			for _, finding := range findings {
+				if finding.Severity == linters.SeverityError {
+					marker = errorStyle.Render("●")
+					break
+				}
+			}
+		}
+
+		number := fmt.Sprintf("%*d", numberWidth, i+1)
+		if focused && i == p.cursor {
+			number = cursorStyle.Render(number)
+		} else {
+			number = numberStyle.Render(number)
+		}
+
+		b.WriteString(marker + number + " " + renderSpans(p.spans[i], codeWidth) + "\n")
+	}
+
+	// Footer with the findings of the cursor line, or the position
+	b.WriteString("\n")
+	findings := p.findings[p.cursor+1]
+	if !focused || len(findings) == 0 {
+		b.WriteString(infoStyle.Render(fmt.Sprintf("Line %d/%d", p.cursor+1, len(p.lines))))
+		return b.String()
+	}
+	finding := findings[0]
+	style := warningStyle
+	if finding.Severity == linters.SeverityError {
+		style = errorStyle
+	}
+	message := fmt.Sprintf("%s: %s", finding.Linter, finding.Message)
+	if len(findings) > 1 {
+		message += fmt.Sprintf(" (+%d more)", len(findings)-1)
+	}
+	if runes := []rune(message); len(runes) > p.width {
+		message = string(runes[:max(p.width-1, 0)]) + "…"
+	}
+	b.WriteString(style.Render(message))
+	return b.String()
+}
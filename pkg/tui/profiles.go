@@ -0,0 +1,61 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// profileActions builds the actions switching to each profile, and back to
+// no profile
+func profileActions(profiles []string) []Action {
+	actions := []Action{
+		{ID: "clear_profile", Title: "Switch profile: none", Tab: anyTab,
+			Run: func(m *Model) tea.Cmd {
+				m.setProfile("")
+				return nil
+			}},
+	}
+
+	for _, profile := range profiles {
+		profile := profile
+		actions = append(actions, Action{
+			ID: "profile_" + profile, Title: "Switch profile: " + profile, Tab: anyTab,
+			Run: func(m *Model) tea.Cmd {
+				m.setProfile(profile)
+				return nil
+			},
+		})
+	}
+	return actions
+}
+
+// setProfile activates the profile with the given name, or none, configuring
+// the linters of the registry again
+func (m *Model) setProfile(name string) {
+	m.config.Profile = name
+
+	// Configuring the linters replaces the status when they reject their options
+	if name == "" {
+		m.status = "No profile"
+	} else {
+		m.status = "Profile: " + name
+		if description := m.config.Profiles[name].Description; description != "" {
+			m.status += " - " + description
+		}
+	}
+	m.configureLinters()
+}
+
+// cycleProfile switches to the next profile, going through no profile after
+// the last one
+func (m *Model) cycleProfile() {
+	profiles := append(m.config.ProfileNames(), "")
+
+	next := 0
+	for i, name := range profiles {
+		if name == m.config.Profile {
+			next = (i + 1) % len(profiles)
+			break
+		}
+	}
+	m.setProfile(profiles[next])
+}
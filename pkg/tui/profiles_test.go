@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/linters"
+)
+
+// linterNames returns the names of the linters
+func linterNames(list []linters.Linter) []string {
+	var names []string
+	for _, linter := range list {
+		names = append(names, linter.Name())
+	}
+	return names
+}
+
+func TestCycleProfile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Point every linter at an executable that exists, so all are available
+	cfg := config.DefaultConfig()
+	for _, options := range cfg.Linters {
+		options["path"] = "true"
+	}
+	registry := linters.DefaultRegistry()
+	for _, linter := range registry.GetAll() {
+		linter.Configure(cfg.LinterOptions(linter.Name()))
+	}
+
+	m := NewModel(cfg, registry)
+	if got := len(m.activeLinters); got != 5 {
+		t.Fatalf("Got %d active linters without a profile, want 5", got)
+	}
+
+	// Profiles go in alphabetical order, then back to none
+	want := []struct {
+		profile string
+		linters int
+	}{
+		{"ci", 5},
+		{"full", 5},
+		{"quick", 2},
+		{"", 5},
+	}
+	for _, w := range want {
+		m.cycleProfile()
+		if m.config.Profile != w.profile || len(m.activeLinters) != w.linters {
+			t.Errorf("Profile %q with linters %v, want %q with %d linters",
+				m.config.Profile, linterNames(m.activeLinters), w.profile, w.linters)
+		}
+	}
+
+	// Picking a profile from the palette configures the tools pane too
+	m.actionByID("profile_quick").Run(&m)
+	for _, pane := range m.panes {
+		if tools, ok := pane.(*ToolsPane); ok && len(tools.tools) != 2 {
+			t.Errorf("Tools pane lists %v, want the linters of the quick profile", tools.tools)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/crixuamg/pkg/config"
+)
+
+// configChangedMsg is sent when a watched config file has been written
+type configChangedMsg struct {
+	path string
+}
+
+// WatchConfig reloads the configuration whenever one of the given files
+// changes, loading it again with opts. The explorer selection and the
+// results are kept.
+func (m *Model) WatchConfig(opts config.LoadOptions, files []string) {
+	// Editors often write a file several times on save, a single pending
+	// change is enough
+	changes := make(chan string, 1)
+	err := config.Watch(files, func(path string) {
+		select {
+		case changes <- path:
+		default:
+		}
+	})
+	if err != nil {
+		m.status = fmt.Sprintf("Config changes aren't reloaded: %s", err)
+		return
+	}
+
+	m.loadOptions = opts
+	m.configChanges = changes
+}
+
+// waitForConfigChange waits for the next change of a watched config file
+func waitForConfigChange(changes <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		return configChangedMsg{path: <-changes}
+	}
+}
+
+// reloadConfig loads the configuration again after the file at path changed.
+// An invalid configuration is reported and the running one kept.
+func (m *Model) reloadConfig(path string) {
+	name := filepath.Base(path)
+
+	loaded, err := config.Load(m.loadOptions)
+	var invalid *config.ValidationError
+	if errors.As(err, &invalid) {
+		for _, problem := range invalid.Problems {
+			if !problem.Warning {
+				m.status = fmt.Sprintf("Config not reloaded: %s", problem)
+				return
+			}
+		}
+	}
+	if err != nil {
+		m.status = fmt.Sprintf("Config not reloaded: %s", err)
+		return
+	}
+
+	// Applying the config reports linters rejecting their options instead
+	m.status = "Reloaded " + name
+	if n := len(loaded.Warnings); n > 0 {
+		m.status += fmt.Sprintf(" with %d warning(s), run lazylint config validate", n)
+	}
+	if err := m.applyConfig(loaded.Config); err != nil {
+		m.status = fmt.Sprintf("Config not reloaded: %s", err)
+	}
+}
+
+// configureLinters configures every linter of the registry with the options
+// of the config and the active profile. Options that a linter rejects are
+// reported in the status bar.
+func (m *Model) configureLinters() {
+	var problems []string
+	for _, linter := range m.registry.GetAll() {
+		if err := linter.Configure(m.config.LinterOptions(linter.Name())); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", linter.Name(), err))
+		}
+	}
+	m.refreshLinters()
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		m.status = "Failed to configure " + strings.Join(problems, "; ")
+	}
+}
+
+// rebuildActions builds the actions again after the linters, themes or
+// profiles changed. Keys that can't be bound are reported in the status bar.
+func (m *Model) rebuildActions() {
+	actions, err := newActions(m.config, m.registry, m.activeLinters)
+	if err != nil {
+		m.status = err.Error()
+	}
+	m.actions = actions
+	m.explorer.focusKeys = keysFor(m.actions, "explorer_focus_preview")
+}
+
+// applyConfig replaces the running configuration: it configures the linters
+// again, applies the theme, the keys and the layout settings
+func (m *Model) applyConfig(cfg *config.Config) error {
+	// Check the keys first, so that a conflict keeps the running configuration.
+	// They're checked against all registered linters, whether the new options
+	// make them available or not.
+	if err := ValidateKeys(cfg, m.registry); err != nil {
+		return err
+	}
+
+	// Keep the config shared with the rest of the UI
+	*m.config = *cfg
+
+	m.configureLinters()
+	InitTheme(m.config)
+	m.rebuildActions()
+
+	if layout := m.config.UI.Layout; layout == config.LayoutTabs || layout == config.LayoutPanes {
+		m.layout = layout
+	}
+	m.split = clampSplit(m.config.UI.Split)
+	m.resize()
+	return nil
+}
@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/linters"
+)
+
+func TestReloadConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	path := filepath.Join(dir, "lazylint.yaml")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+	}
+	write("ui:\n  theme: light\n")
+
+	loaded, err := config.Load(config.LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	m := NewModel(loaded.Config, linters.DefaultRegistry())
+	m.loadOptions = config.LoadOptions{}
+
+	// A valid change applies to the running configuration
+	write("ui:\n  theme: catppuccin\nlinters:\n  phpstan:\n    args: [analyse, --level=8]\nkeys:\n  quit: x\n")
+	m.reloadConfig(path)
+	if m.status != "Reloaded lazylint.yaml" {
+		t.Errorf("Status = %q", m.status)
+	}
+	if m.config.UI.Theme != "catppuccin" || CurrentTheme.Name != Themes["catppuccin"].Name {
+		t.Errorf("Theme = %q, want catppuccin applied", m.config.UI.Theme)
+	}
+	if got := optionArgs(m.config.Linters["phpstan"]["args"]); strings.Join(got, " ") != "analyse --level=8" {
+		t.Errorf("phpstan args = %q", got)
+	}
+	if action := m.actionForKey("x"); action == nil || action.ID != "quit" {
+		t.Errorf("Expected x to quit after the reload, got %v", action)
+	}
+
+	// An invalid change is reported and keeps the running configuration
+	write("ui:\n  theme: light\n  split: wide\n")
+	m.reloadConfig(path)
+	if !strings.HasPrefix(m.status, "Config not reloaded: ") || !strings.Contains(m.status, "ui.split") {
+		t.Errorf("Status = %q, want the validation error", m.status)
+	}
+	if m.config.UI.Theme != "catppuccin" {
+		t.Errorf("Theme = %q, want the running one kept", m.config.UI.Theme)
+	}
+}
+
+func TestReloadLinterKeys(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	// PHPStan is available, so run_phpstan can be bound
+	phpstanPath := filepath.Join(dir, "phpstan")
+	if err := os.WriteFile(phpstanPath, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "lazylint.yaml")
+	content := "linters:\n  phpstan:\n    path: " + phpstanPath + "\nkeys:\n  run_phpstan: ctrl+y\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := config.Load(config.LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	registry := linters.NewRegistry()
+	phpstan := linters.NewPHPStan()
+	phpstan.Configure(loaded.Config.Linters["phpstan"])
+	registry.Register(phpstan)
+	if err := ValidateKeys(loaded.Config, registry); err != nil {
+		t.Fatalf("ValidateKeys failed: %v", err)
+	}
+	m := NewModel(loaded.Config, registry)
+
+	// Reloading keeps the binding
+	if err := os.WriteFile(path, []byte(content+"  quit: x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m.reloadConfig(path)
+	if m.status != "Reloaded lazylint.yaml" {
+		t.Fatalf("Status = %q", m.status)
+	}
+	if action := m.actionForKey("ctrl+y"); action == nil || action.ID != "run_phpstan" {
+		t.Errorf("Expected ctrl+y to run PHPStan after the reload, got %v", action)
+	}
+}
+
+// rejectingLinter refuses every configuration
+type rejectingLinter struct {
+	missingLinter
+}
+
+func (rejectingLinter) Configure(options map[string]interface{}) error {
+	return fmt.Errorf("invalid options")
+}
+
+func TestConfigureLintersReportsErrors(t *testing.T) {
+	registry := linters.NewRegistry()
+	registry.Register(rejectingLinter{})
+	m := Model{
+		config:   &config.Config{Linters: map[string]map[string]interface{}{"missing": {"path": "missing"}}},
+		registry: registry,
+	}
+
+	m.configureLinters()
+	if m.status != "Failed to configure missing: invalid options" {
+		t.Errorf("Status = %q, want the rejected options", m.status)
+	}
+}
@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/crixuamg/pkg/git"
+)
+
+// repoRefreshInterval is how often the repository pane refreshes its status
+const repoRefreshInterval = 5 * time.Second
+
+// repoInfoMsg carries the refreshed state of the repository
+type repoInfoMsg struct {
+	root       string
+	info       *git.RepoInfo // nil outside a git repository
+	toolchains []string
+}
+
+// repoTickMsg triggers the periodic refresh of the repository pane
+type repoTickMsg struct{}
+
+// repoTick schedules the next refresh of the repository pane
+func repoTick() tea.Cmd {
+	return tea.Tick(repoRefreshInterval, func(time.Time) tea.Msg {
+		return repoTickMsg{}
+	})
+}
+
+// loadRepoInfo reads the git status and the toolchains of the repository at dir
+func loadRepoInfo(dir string) tea.Cmd {
+	return func() tea.Msg {
+		msg := repoInfoMsg{root: dir, toolchains: detectToolchains(dir)}
+		if info, err := git.Info(dir); err == nil {
+			msg.info = info
+		}
+		return msg
+	}
+}
+
+// detectToolchains describes the toolchains of the project at dir from its
+// manifests, e.g. "Go 1.24" or "JavaScript (pnpm)"
+func detectToolchains(dir string) []string {
+	var toolchains []string
+
+	if exists(filepath.Join(dir, "composer.json")) {
+		name := "PHP (composer)"
+		if constraint := composerPHP(filepath.Join(dir, "composer.json")); constraint != "" {
+			name = "PHP " + constraint + " (composer)"
+		}
+		toolchains = append(toolchains, name)
+	}
+
+	if exists(filepath.Join(dir, "go.mod")) {
+		name := "Go"
+		if version := goVersion(filepath.Join(dir, "go.mod")); version != "" {
+			name += " " + version
+		}
+		toolchains = append(toolchains, name)
+	}
+
+	if exists(filepath.Join(dir, "package.json")) {
+		manager := "npm"
+		switch {
+		case exists(filepath.Join(dir, "pnpm-lock.yaml")):
+			manager = "pnpm"
+		case exists(filepath.Join(dir, "yarn.lock")):
+			manager = "yarn"
+		case exists(filepath.Join(dir, "bun.lockb")):
+			manager = "bun"
+		}
+		name := "JavaScript"
+		if exists(filepath.Join(dir, "tsconfig.json")) {
+			name = "TypeScript"
+		}
+		toolchains = append(toolchains, name+" ("+manager+")")
+	}
+
+	return toolchains
+}
+
+// exists reports whether a file exists at path
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// goVersion returns the go directive of a go.mod file
+func goVersion(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) == 2 && fields[0] == "go" {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// composerPHP returns the PHP version constraint required by a composer.json
+func composerPHP(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	var manifest struct {
+		Require map[string]string `json:"require"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return ""
+	}
+	return manifest.Require["php"]
+}
@@ -0,0 +1,33 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDetectToolchains(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"composer.json":  `{"require": {"php": "^8.2"}}`,
+		"go.mod":         "module example.com/app\n\ngo 1.24.3\n",
+		"package.json":   "{}",
+		"pnpm-lock.yaml": "",
+		"tsconfig.json":  "{}",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	want := []string{"PHP ^8.2 (composer)", "Go 1.24.3", "TypeScript (pnpm)"}
+	if got := detectToolchains(dir); !reflect.DeepEqual(got, want) {
+		t.Errorf("detectToolchains() = %v, want %v", got, want)
+	}
+
+	if got := detectToolchains(t.TempDir()); len(got) != 0 {
+		t.Errorf("detectToolchains() of an empty directory = %v, want none", got)
+	}
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/crixuamg/pkg/linters"
+)
+
+// updateViewportContent updates the viewport content based on the results.
+// Every line is recorded in resultRows with the finding it shows, if any, so
+// findings can be selected and opened.
+func (m *Model) updateViewportContent() {
+	var content strings.Builder
+	m.resultRows = nil
+	addLine := func(line string, finding *linters.Finding) {
+		content.WriteString(line + "\n")
+		m.resultRows = append(m.resultRows, finding)
+	}
+
+	root := m.explorer.rootDir
+	if m.resultsPath != "" {
+		// Show the findings of the file or directory picked in the explorer
+		rel, err := filepath.Rel(root, m.resultsPath)
+		if err != nil {
+			rel = m.resultsPath
+		}
+		addLine(subtitleStyle.Render(fmt.Sprintf("Findings in %s", rel))+" "+infoStyle.Render("(esc: show all results)"), nil)
+		addLine("", nil)
+
+		findings := findingsBelow(m.resultList(), root, m.resultsPath)
+		if len(findings) == 0 {
+			addLine(infoStyle.Render("No findings"), nil)
+		}
+		for i := range findings {
+			addLine(m.renderFinding(findings[i], len(m.resultRows)), &findings[i])
+		}
+	} else {
+		for _, result := range m.resultList() {
+			addLine(subtitleStyle.Render(fmt.Sprintf("%s Results", result.Label())), nil)
+
+			statusLine := infoStyle.Render(fmt.Sprintf("Completed in %.2fs", result.Duration.Seconds()))
+			if result.Success {
+				addLine(successStyle.Render(fmt.Sprintf("✓ %s completed successfully", result.Label()))+" "+statusLine, nil)
+			} else {
+				addLine(errorStyle.Render(fmt.Sprintf("✗ %s found issues", result.Label()))+" "+statusLine, nil)
+			}
+			addLine("", nil)
+
+			// Prefer the parsed findings, fall back to the raw output
+			findings := linters.ParseFindings(result)
+			for i := range findings {
+				if findings[i].File != "" {
+					findings[i].File = resolveFindingPath(root, findings[i].File)
+				}
+				addLine(m.renderFinding(findings[i], len(m.resultRows)), &findings[i])
+			}
+			if len(findings) == 0 {
+				if output := strings.TrimRight(result.Output, "\n"); output != "" {
+					for _, line := range strings.Split(output, "\n") {
+						addLine(line, nil)
+					}
+				} else {
+					addLine(infoStyle.Render("No output from linter"), nil)
+				}
+			}
+
+			// Always show stderr, it explains crashes and configuration problems
+			if result.Error != "" {
+				addLine("", nil)
+				addLine(errorStyle.Render("Errors:"), nil)
+				for _, line := range strings.Split(strings.TrimRight(result.Error, "\n"), "\n") {
+					addLine(errorStyle.Render(line), nil)
+				}
+			}
+			addLine("", nil)
+		}
+	}
+
+	if m.resultCursor >= len(m.resultRows) || (m.resultCursor >= 0 && m.resultRows[m.resultCursor] == nil) {
+		m.resultCursor = -1
+	}
+	m.viewport.SetContent(strings.TrimSuffix(content.String(), "\n"))
+}
+
+// renderFinding renders a finding as a line of the results view
+func (m Model) renderFinding(finding linters.Finding, row int) string {
+	location := finding.File
+	if rel, err := filepath.Rel(m.explorer.rootDir, finding.File); err == nil && !strings.HasPrefix(rel, "..") {
+		location = rel
+	}
+	if finding.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, finding.Line)
+	}
+
+	severity := infoStyle.Render(string(finding.Severity))
+	switch finding.Severity {
+	case linters.SeverityError:
+		severity = errorStyle.Render(string(finding.Severity))
+	case linters.SeverityWarning:
+		severity = warningStyle.Render(string(finding.Severity))
+	}
+
+	prefix := "  "
+	if row == m.resultCursor {
+		prefix = selectedItemStyle.Render("> ")
+	}
+	return fmt.Sprintf("%s%s %s %s %s", prefix, location, severity, finding.Message, infoStyle.Render("("+finding.Linter+")"))
+}
+
+// moveResultCursor moves the cursor to the next finding, or the previous one
+// when delta is negative, and scrolls it into view
+func (m *Model) moveResultCursor(delta int) {
+	for row := m.resultCursor + delta; row >= 0 && row < len(m.resultRows); row += delta {
+		if m.resultRows[row] != nil {
+			m.selectResultRow(row)
+			return
+		}
+	}
+}
+
+// selectResultRow moves the cursor to the row and scrolls it into view
+func (m *Model) selectResultRow(row int) {
+	m.resultCursor = row
+	offset := m.viewport.YOffset
+	visible := m.viewport.Height - m.viewport.Style.GetVerticalFrameSize()
+	m.updateViewportContent()
+	if row < offset {
+		offset = row
+	} else if row >= offset+visible {
+		offset = row - visible + 1
+	}
+	m.viewport.SetYOffset(offset)
+}
+
+// openResult opens the preview of the finding under the cursor in the explorer
+func (m *Model) openResult() {
+	if m.resultCursor < 0 || m.resultCursor >= len(m.resultRows) {
+		return
+	}
+	finding := m.resultRows[m.resultCursor]
+	if finding == nil || finding.File == "" {
+		return
+	}
+
+	m.explorer.OpenFile(finding.File, finding.Line)
+	m.activeTab = 0
+}
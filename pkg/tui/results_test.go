@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/linters"
+)
+
+func TestResultsShowStderrWithFindings(t *testing.T) {
+	m := NewModel(config.DefaultConfig(), linters.DefaultRegistry())
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+
+	m.results = map[string]*linters.Result{
+		"golangci-lint": {
+			Name:   "golangci-lint",
+			Output: "main.go:3:1: unused function (unused)\n",
+			Error:  "level=warning msg=\"deprecated linter\"\n",
+		},
+	}
+	m.activeTab = 2
+	m.updateViewportContent()
+
+	view := m.viewport.View()
+	if !strings.Contains(view, "unused function") || !strings.Contains(view, "deprecated linter") {
+		t.Errorf("results should show the findings and stderr, got:\n%s", view)
+	}
+
+	// The viewport fits between the header and the footer
+	if height := lipgloss.Height(m.View()); height != 40 {
+		t.Errorf("view is %d lines high, want 40", height)
+	}
+}
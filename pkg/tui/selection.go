@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/crixuamg/pkg/config"
+)
+
+// selectionFile is where the explorer selection is persisted, relative to the repository root
+var selectionFile = filepath.Join(".lazylint", "selection.json")
+
+// loadSelection reads the persisted selection of the repository at root and
+// returns the absolute paths of the selected files that still exist
+func loadSelection(root string) map[string]bool {
+	selected := make(map[string]bool)
+
+	data, err := os.ReadFile(filepath.Join(root, selectionFile))
+	if err != nil {
+		return selected
+	}
+
+	var files []string
+	if err := json.Unmarshal(data, &files); err != nil {
+		return selected
+	}
+
+	for _, file := range files {
+		full := filepath.Join(root, filepath.FromSlash(file))
+		if _, err := os.Stat(full); err == nil {
+			selected[full] = true
+		}
+	}
+	return selected
+}
+
+// saveSelection persists the selected files as paths relative to root
+func saveSelection(root string, selected map[string]bool) error {
+	files := make([]string, 0, len(selected))
+	for full := range selected {
+		rel, err := filepath.Rel(root, full)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		files = append(files, filepath.ToSlash(rel))
+	}
+	sort.Strings(files)
+
+	data, err := json.MarshalIndent(files, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	target := filepath.Join(root, selectionFile)
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(target, data, 0644)
+}
+
+// matchGlob reports whether the slash-separated relative path matches the
+// pattern, see config.MatchGlob
+func matchGlob(pattern, rel string) bool {
+	return config.MatchGlob(pattern, rel)
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchGlob(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"src/**/*.php", "src/App.php", true},
+		{"src/**/*.php", "src/Http/Controllers/Home.php", true},
+		{"src/**/*.php", "lib/App.php", false},
+		{"src/*.php", "src/Http/Home.php", false},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "pkg/tui/model.go", true},
+		{"**", "any/path/at/all.txt", true},
+		{"*.js", "src/app.js", false},
+	}
+
+	for _, tc := range testCases {
+		if got := matchGlob(tc.pattern, tc.path); got != tc.want {
+			t.Errorf("matchGlob(%q, %q) = %t, want %t", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestSelectionRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{"src/App.php", "main.go"} {
+		path := filepath.Join(root, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	selected := map[string]bool{
+		filepath.Join(root, "src/App.php"): true,
+		filepath.Join(root, "main.go"):     true,
+		filepath.Join(root, "deleted.go"):  true,
+	}
+	if err := saveSelection(root, selected); err != nil {
+		t.Fatalf("saveSelection() error = %v", err)
+	}
+
+	loaded := loadSelection(root)
+	if len(loaded) != 2 {
+		t.Fatalf("loadSelection() returned %d files, want 2: %v", len(loaded), loaded)
+	}
+	if !loaded[filepath.Join(root, "src/App.php")] || !loaded[filepath.Join(root, "main.go")] {
+		t.Errorf("loadSelection() = %v, missing saved files", loaded)
+	}
+}
+
+func TestSelectTargets(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{"src/App.php", "src/Http/Home.php", "lib/util.php", "main.go"} {
+		path := filepath.Join(root, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	e := NewExplorer(80, 24)
+	e.SelectTargets([]string{filepath.Join(root, "main.go"), filepath.Join(root, "src")})
+
+	if e.rootDir != root || e.currentDir != root {
+		t.Errorf("root = %s, current = %s, want %s", e.rootDir, e.currentDir, root)
+	}
+	if e.preview == nil || e.preview.path != filepath.Join(root, "main.go") {
+		t.Error("expected a preview of main.go")
+	}
+
+	want := []string{
+		filepath.Join(root, "main.go"),
+		filepath.Join(root, "src", "App.php"),
+		filepath.Join(root, "src", "Http", "Home.php"),
+	}
+	got := e.GetSelectedFiles()
+	if len(got) != len(want) {
+		t.Fatalf("selected %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("selected %v, want %v", got, want)
+			break
+		}
+	}
+}
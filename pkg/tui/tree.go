@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/crixuamg/pkg/linters"
+)
+
+// findingCounts holds the number of errors and warnings of a file or directory
+type findingCounts struct {
+	errors   int
+	warnings int
+}
+
+// String renders the counts colored by severity
+func (c findingCounts) String() string {
+	var parts []string
+	if c.errors > 0 {
+		parts = append(parts, errorStyle.Render(fmt.Sprintf("%dE", c.errors)))
+	}
+	if c.warnings > 0 {
+		parts = append(parts, warningStyle.Render(fmt.Sprintf("%dW", c.warnings)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// resolveFindingPath returns the absolute path of a file reported by a linter.
+// Relative paths are tried against the working directory and then the root.
+func resolveFindingPath(root, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		if _, err := os.Stat(abs); err == nil {
+			return abs
+		}
+	}
+	return filepath.Join(root, path)
+}
+
+// countFindings aggregates the findings of the results per file and per
+// directory up to the root
+func countFindings(results []*linters.Result, root string) map[string]findingCounts {
+	counts := make(map[string]findingCounts)
+	for _, result := range results {
+		for _, finding := range linters.ParseFindings(result) {
+			if finding.File == "" {
+				continue
+			}
+
+			path := resolveFindingPath(root, finding.File)
+			rel, err := filepath.Rel(root, path)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				continue
+			}
+
+			// Add the finding to the file and all of its parent directories
+			for {
+				c := counts[path]
+				switch finding.Severity {
+				case linters.SeverityError:
+					c.errors++
+				case linters.SeverityWarning:
+					c.warnings++
+				}
+				counts[path] = c
+
+				if path == root {
+					break
+				}
+				path = filepath.Dir(path)
+			}
+		}
+	}
+	return counts
+}
+
+// findingsBelow returns the findings of the results for the given file or
+// the files below the given directory, ordered by file and line
+func findingsBelow(results []*linters.Result, root, path string) []linters.Finding {
+	var findings []linters.Finding
+	for _, result := range results {
+		for _, finding := range linters.ParseFindings(result) {
+			if finding.File == "" {
+				continue
+			}
+
+			file := resolveFindingPath(root, finding.File)
+			rel, err := filepath.Rel(path, file)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				continue
+			}
+
+			finding.File = file
+			findings = append(findings, finding)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+	return findings
+}
+
+// treeNode is a directory or file in the explorer tree
+type treeNode struct {
+	path     string
+	isDir    bool
+	children map[string]*treeNode
+}
+
+// buildTree arranges the given absolute file paths below root into a tree
+func buildTree(root string, files []string) *treeNode {
+	tree := &treeNode{path: root, isDir: true, children: make(map[string]*treeNode)}
+	for _, file := range files {
+		rel, err := filepath.Rel(root, file)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+
+		node := tree
+		parts := strings.Split(rel, string(os.PathSeparator))
+		for i, part := range parts {
+			child, ok := node.children[part]
+			if !ok {
+				child = &treeNode{
+					path:     filepath.Join(node.path, part),
+					isDir:    i < len(parts)-1,
+					children: make(map[string]*treeNode),
+				}
+				node.children[part] = child
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+// sortedChildren returns the children of the node, directories first and
+// then by name
+func (n *treeNode) sortedChildren() []*treeNode {
+	children := make([]*treeNode, 0, len(n.children))
+	for _, child := range n.children {
+		children = append(children, child)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].isDir != children[j].isDir {
+			return children[i].isDir
+		}
+		return filepath.Base(children[i].path) < filepath.Base(children[j].path)
+	})
+	return children
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/crixuamg/pkg/linters"
+)
+
+func TestCountFindings(t *testing.T) {
+	root := t.TempDir()
+	controller := filepath.Join(root, "src", "Http", "Controller.php")
+	model := filepath.Join(root, "src", "Model.php")
+
+	results := []*linters.Result{
+		{Name: "eslint", Output: "\n" + controller + "\n  3:7  error  Broken  rule\n  9:1  warning  Odd  rule\n\n" + model + "\n  1:1  error  Broken  rule\n"},
+		{Name: "golangci-lint", Output: "/elsewhere/main.go:1:1: outside the root (vet)\n"},
+	}
+
+	counts := countFindings(results, root)
+
+	testCases := []struct {
+		path string
+		want findingCounts
+	}{
+		{controller, findingCounts{errors: 1, warnings: 1}},
+		{model, findingCounts{errors: 1}},
+		{filepath.Join(root, "src", "Http"), findingCounts{errors: 1, warnings: 1}},
+		{filepath.Join(root, "src"), findingCounts{errors: 2, warnings: 1}},
+		{root, findingCounts{errors: 2, warnings: 1}},
+		{"/elsewhere/main.go", findingCounts{}},
+	}
+
+	for _, tc := range testCases {
+		if got := counts[tc.path]; got != tc.want {
+			t.Errorf("counts[%q] = %+v, want %+v", tc.path, got, tc.want)
+		}
+	}
+
+	if findings := findingsBelow(results, root, filepath.Join(root, "src", "Http")); len(findings) != 2 {
+		t.Errorf("findingsBelow() returned %d findings, want 2", len(findings))
+	}
+}
+
+func TestBuildTree(t *testing.T) {
+	root := "/repo"
+	tree := buildTree(root, []string{"/repo/main.go", "/repo/src/b.php", "/repo/src/a.php", "/other/x.go"})
+
+	children := tree.sortedChildren()
+	if len(children) != 2 {
+		t.Fatalf("root has %d children, want 2", len(children))
+	}
+	if children[0].path != "/repo/src" || !children[0].isDir {
+		t.Errorf("first child = %+v, want the src directory", children[0])
+	}
+	if children[1].path != "/repo/main.go" || children[1].isDir {
+		t.Errorf("second child = %+v, want main.go", children[1])
+	}
+
+	files := children[0].sortedChildren()
+	if len(files) != 2 || files[0].path != "/repo/src/a.php" || files[1].path != "/repo/src/b.php" {
+		t.Errorf("src children are not sorted by name: %+v", files)
+	}
+}
@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/crixuamg/pkg/history"
+)
+
+// sparkBlocks are the glyphs used to draw sparklines, from low to high
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// maxCommitBars is the number of commits shown in the per-commit chart
+const maxCommitBars = 10
+
+// sparkline renders the given values as a single line of block glyphs.
+// Only the last width values are drawn.
+func sparkline(values []float64, width int) string {
+	if width <= 0 || len(values) == 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if hi > lo {
+			idx = int((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1))
+		} else if hi > 0 {
+			idx = len(sparkBlocks) / 2
+		}
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
+
+// trendArrow describes the change between the first and last value
+func trendArrow(values []float64) string {
+	if len(values) < 2 {
+		return ""
+	}
+	first, last := values[0], values[len(values)-1]
+	switch {
+	case last < first:
+		return successStyle.Render(fmt.Sprintf("▼ %g", first-last))
+	case last > first:
+		return errorStyle.Render(fmt.Sprintf("▲ %g", last-first))
+	default:
+		return infoStyle.Render("＝")
+	}
+}
+
+// chartPalette returns the colors used to tell linters apart in charts
+func chartPalette() []lipgloss.Color {
+	return []lipgloss.Color{primary, accent, warningClr, errorClr, info, subtext}
+}
+
+// renderTrendsTab renders the quality trend dashboard
+func (m Model) renderTrendsTab(width int) string {
+	title := titleStyle.Render("Quality Trends")
+
+	if len(m.runs) == 0 {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			title,
+			infoStyle.Render("No stored runs yet. Completed runs are recorded automatically."),
+		)
+	}
+
+	names := history.LinterNames(m.runs)
+	labelWidth := 16
+	for _, name := range names {
+		if len(name)+2 > labelWidth {
+			labelWidth = len(name) + 2
+		}
+	}
+	chartWidth := width - labelWidth - 20
+	if chartWidth < 10 {
+		chartWidth = 10
+	}
+
+	runs := m.runs
+	if len(runs) > chartWidth {
+		runs = runs[len(runs)-chartWidth:]
+	}
+
+	var content strings.Builder
+	palette := chartPalette()
+
+	// Findings per linter over time
+	content.WriteString(subtitleStyle.Render(fmt.Sprintf("Findings per linter (last %d runs)", len(runs))))
+	content.WriteString("\n")
+	for i, name := range names {
+		values, skipped := linterSeries(runs, name)
+		if len(values) == 0 {
+			continue
+		}
+
+		// Align the line with the runs, leaving blank the ones before the linter's first
+		line := lipgloss.NewStyle().Foreground(palette[i%len(palette)]).Render(sparkline(values, chartWidth))
+		content.WriteString(fmt.Sprintf("%-*s %s%s %4.0f %s\n", labelWidth, name, strings.Repeat(" ", skipped), line, values[len(values)-1], trendArrow(values)))
+	}
+
+	// Total run duration over time
+	var durations []float64
+	for _, run := range runs {
+		durations = append(durations, run.Duration.Seconds())
+	}
+	content.WriteString("\n")
+	content.WriteString(subtitleStyle.Render("Run duration"))
+	content.WriteString("\n")
+	latest := runs[len(runs)-1].Duration.Round(100 * time.Millisecond)
+	content.WriteString(fmt.Sprintf("%-*s %s %s\n", labelWidth, "total", infoStyle.Render(sparkline(durations, chartWidth)), latest))
+
+	// Findings per commit as stacked bars
+	commits := history.ByCommit(m.runs)
+	if len(commits) > maxCommitBars {
+		commits = commits[len(commits)-maxCommitBars:]
+	}
+	if len(commits) > 0 {
+		content.WriteString("\n")
+		content.WriteString(subtitleStyle.Render("Findings per commit"))
+		content.WriteString("\n")
+		content.WriteString(renderCommitBars(commits, names, chartWidth, labelWidth))
+
+		// Legend
+		var legend []string
+		for i, name := range names {
+			legend = append(legend, lipgloss.NewStyle().Foreground(palette[i%len(palette)]).Render("█ "+name))
+		}
+		content.WriteString(strings.Join(legend, "  "))
+		content.WriteString("\n")
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		content.String(),
+	)
+}
+
+// linterSeries returns the findings of the linter in each run. A run that
+// didn't include the linter, e.g. a run of a single other linter, carries its
+// last count forward; the runs before its first one are skipped and counted.
+func linterSeries(runs []history.Run, name string) (values []float64, skipped int) {
+	for _, run := range runs {
+		stats, ok := run.Linters[name]
+		switch {
+		case ok:
+			values = append(values, float64(stats.Findings))
+		case len(values) > 0:
+			values = append(values, values[len(values)-1])
+		default:
+			skipped++
+		}
+	}
+	return values, skipped
+}
+
+// renderCommitBars renders one horizontal stacked bar per commit
+func renderCommitBars(commits []history.Run, names []string, width, labelWidth int) string {
+	maxTotal := 0
+	for _, run := range commits {
+		if total := run.Total(); total > maxTotal {
+			maxTotal = total
+		}
+	}
+
+	palette := chartPalette()
+	var b strings.Builder
+	for _, run := range commits {
+		b.WriteString(fmt.Sprintf("%-*s ", labelWidth, run.Commit))
+		for i, name := range names {
+			count := run.Linters[name].Findings
+			if count == 0 || maxTotal == 0 {
+				continue
+			}
+			cells := int(math.Round(float64(count) / float64(maxTotal) * float64(width)))
+			if cells == 0 {
+				cells = 1
+			}
+			b.WriteString(lipgloss.NewStyle().Foreground(palette[i%len(palette)]).Render(strings.Repeat("█", cells)))
+		}
+		b.WriteString(fmt.Sprintf(" %d\n", run.Total()))
+	}
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/history"
+	"github.com/crixuamg/pkg/linters"
+)
+
+func TestLinterSeries(t *testing.T) {
+	stats := func(findings int) history.LinterStats { return history.LinterStats{Findings: findings} }
+	runs := []history.Run{
+		{Linters: map[string]history.LinterStats{"php": stats(1)}},
+		{Linters: map[string]history.LinterStats{"php": stats(2), "phpstan": stats(8)}},
+		{Linters: map[string]history.LinterStats{"php": stats(3)}},
+		{Linters: map[string]history.LinterStats{"phpstan": stats(6)}},
+	}
+
+	// Runs without PHPStan carry its last count instead of dropping to 0
+	values, skipped := linterSeries(runs, "phpstan")
+	if want := []float64{8, 8, 6}; !reflect.DeepEqual(values, want) || skipped != 1 {
+		t.Errorf("phpstan series = %v skipping %d, want %v skipping 1", values, skipped, want)
+	}
+
+	values, skipped = linterSeries(runs, "php")
+	if want := []float64{1, 2, 3, 3}; !reflect.DeepEqual(values, want) || skipped != 0 {
+		t.Errorf("php series = %v skipping %d, want %v", values, skipped, want)
+	}
+}
+
+func TestHistoryErrorKeepsPending(t *testing.T) {
+	m := NewModel(config.DefaultConfig(), linters.NewRegistry())
+	m.pending = 1
+
+	// A failing history store isn't a linter job
+	next, _ := m.Update(historyMsg{err: "disk full"})
+	m = next.(Model)
+	if m.pending != 1 || m.err != "disk full" {
+		t.Errorf("pending = %d, err = %q, want 1 and the history error", m.pending, m.err)
+	}
+}
@@ -1,11 +1,17 @@
 package tui
 
 import (
+	"time"
+
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/crixuamg/pkg/config"
+	"github.com/crixuamg/pkg/doctor"
+	"github.com/crixuamg/pkg/git"
+	"github.com/crixuamg/pkg/history"
 	"github.com/crixuamg/pkg/linters"
+	"github.com/crixuamg/pkg/workspace"
 )
 
 // State represents the current state of the application
@@ -30,29 +36,82 @@ func (e errorMsg) Error() string {
 	return e.err
 }
 
+// statusMsg is a notification shown in the status bar
+type statusMsg struct {
+	text string
+}
+
+// startRunMsg runs all available linters on the targets
+type startRunMsg struct{}
+
+// historyMsg is sent when the stored runs have been (re)loaded, or with the
+// error that kept them from being stored or loaded
+type historyMsg struct {
+	runs []history.Run
+	err  string
+}
+
 // Model represents the application state
 type Model struct {
-	config      *config.Config
-	registry    *linters.Registry
-	state       State
-	width       int
-	height      int
-	selectedTool int
-	target      string
-	results     map[string]*linters.Result
-	viewport    viewport.Model
-	spinner     spinner.Model
-	help        help.Model
-	keys        keyMap
-	err         string
-	explorer    *Explorer
+	config        *config.Config
+	registry      *linters.Registry
+	state         State
+	width         int
+	height        int
+	selectedTool  int
+	targets       []string
+	runOnStart    bool // Lint the targets given on the command line on start
+	results       map[string]*linters.Result
+	resultsPath   string             // Limits the results tab to the findings below this path
+	resultRows    []*linters.Finding // Finding shown on each line of the results, if any
+	resultCursor  int                // Selected line of the results, -1 for none
+	viewport      *viewport.Model    // Shared by the results tab and the output pane
+	spinner       spinner.Model
+	help          help.Model
+	err           string
+	status        string
+	explorer      *Explorer
+	finder        *Finder
+	palette       *Palette
+	actions       []Action // Registry of all actions, see defaultActions
 	activeLinters []linters.Linter
+	configEditor  *ConfigEditor
+	configPath    string // Project config file the Config tab saves to
+
+	// Hot reload of the config files, see WatchConfig
+	loadOptions   config.LoadOptions
+	configChanges <-chan string
+
+	// Sub-projects of the repository, see SetWorkspace
+	workspace *workspace.Workspace
+
+	// Run tracking and stored history
+	pending    int
+	runStarted time.Time
+	history    *history.Store
+
+	// Staged mode lints the index content through a temporary mirror
+	stagedMode bool
+	mirror     *git.Mirror
+	runs       []history.Run
+
+	// Watch mode runs the linters whenever a selected file is saved
+	watching        bool
+	watchGeneration int
+	watchTimes      map[string]time.Time
+
+	// Diagnosis of the linters shown in the Doctor tab, nil until done
+	doctorChecks []doctor.Check
 
 	// Multi-pane layout
-	panes       []Pane
-	activePaneIndex int
+	layout          string // config.LayoutTabs or config.LayoutPanes
+	split           int    // Width of the left column of the panes layout, in percent
+	dragging        bool   // Whether the split is being dragged with the mouse
+	rightTab        int    // Tab shown in the right column of the panes layout
+	panes           []Pane
+	activePaneIndex int // Focused pane of the panes layout, -1 for none
 
 	// New UI layout
-	activeTab   int // 0: Explorer, 1: Linters, 2: Results, 3: Config
-	useNewUI    bool // Whether to use the new UI
+	activeTab int  // 0: Explorer, 1: Linters, 2: Results, 3: Config, 4: Trends
+	useNewUI  bool // Whether to use the new UI
 }
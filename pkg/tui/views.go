@@ -2,7 +2,6 @@ package tui
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -19,12 +18,14 @@ func renderLogo() string {
 	return logoStyle.Render(logo)
 }
 
+// tabNames holds the titles of the tabs, in order
+var tabNames = []string{"Explorer", "Linters", "Results", "Config", "Trends", "Doctor"}
+
 // renderTabs renders the tab bar
 func (m Model) renderTabs() string {
-	tabs := []string{"Explorer", "Linters", "Results", "Config"}
 	renderedTabs := []string{}
 
-	for i, tab := range tabs {
+	for i, tab := range tabNames {
 		if i == m.activeTab {
 			renderedTabs = append(renderedTabs, activeTabStyle.Render(tab))
 		} else {
@@ -49,6 +50,10 @@ func (m Model) renderTabContent() string {
 		content = m.renderResultsTab(width)
 	case 3: // Config tab
 		content = m.renderConfigTab(width)
+	case 4: // Trends tab
+		content = m.renderTrendsTab(width)
+	case doctorTab:
+		content = m.renderDoctorTab(width)
 	}
 
 	return tabContentStyle.Width(width).Render(content)
@@ -57,31 +62,45 @@ func (m Model) renderTabContent() string {
 // renderExplorerTab renders the explorer tab content
 func (m Model) renderExplorerTab(width int) string {
 	// Adjust explorer dimensions
-	m.explorer.width = width - 4 // Account for padding
+	m.explorer.width = width - 4      // Account for padding
 	m.explorer.height = m.height - 10 // Account for other UI elements
 
 	// Get explorer view
 	explorerView := m.explorer.View()
 
+	// Join all components
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		m.explorerHeader(),
+		explorerView,
+	)
+}
+
+// explorerHeader renders the title and path info above the explorer
+func (m Model) explorerHeader() string {
 	// Add title
 	title := titleStyle.Render("File Explorer")
 
 	// Add path info
 	pathInfo := infoStyle.Render(fmt.Sprintf("Current Directory: %s", m.explorer.currentDir))
+	if m.stagedMode {
+		pathInfo += " " + badgeStyle.Render("STAGED")
+	}
 
-	// Join all components
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
-		title,
-		pathInfo,
-		explorerView,
-	)
+	return lipgloss.JoinVertical(lipgloss.Left, title, pathInfo)
 }
 
 // renderLintersTab renders the linters tab content
 func (m Model) renderLintersTab(width int) string {
-	// Add title
+	// Add title, with the active profile
 	title := titleStyle.Render("Available Linters")
+	if name := m.config.Profile; name != "" {
+		profile := "Profile: " + name
+		if description := m.config.Profiles[name].Description; description != "" {
+			profile += " - " + description
+		}
+		title = lipgloss.JoinVertical(lipgloss.Left, title, infoStyle.Render(profile))
+	}
 
 	// Build linter list
 	var lintersContent strings.Builder
@@ -105,20 +124,18 @@ func (m Model) renderLintersTab(width int) string {
 		buttonStyle.Render("Run All"),
 	)
 
-	// Join all components
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
-		title,
-		lintersContent.String(),
-		"\n",
-		buttons,
-	)
+	// Join all components, with the sub-projects of the repository
+	parts := []string{title, lintersContent.String(), "\n", buttons}
+	if workspace := m.renderWorkspace(); workspace != "" {
+		parts = append(parts, "\n", workspace)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
 }
 
 // renderResultsTab renders the results tab content
 func (m Model) renderResultsTab(width int) string {
 	// Add title
-	title := titleStyle.Render("Linter Results")
+	title := m.resultsHeader()
 
 	// Check if we have results
 	if len(m.results) == 0 {
@@ -129,67 +146,31 @@ func (m Model) renderResultsTab(width int) string {
 		)
 	}
 
-	// Build results content
-	var resultsContent strings.Builder
-	for name, result := range m.results {
-		resultsContent.WriteString(subtitleStyle.Render(fmt.Sprintf("%s Results", name)))
-		resultsContent.WriteString("\n\n")
-
-		if result.Output != "" {
-			resultsContent.WriteString(result.Output)
-		} else {
-			resultsContent.WriteString(infoStyle.Render("No output from linter"))
-		}
-
-		resultsContent.WriteString("\n\n")
-	}
-
 	// Join all components
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		title,
-		resultsContent.String(),
+		m.viewport.View(),
 	)
 }
 
-// renderConfigTab renders the config tab content
-func (m Model) renderConfigTab(width int) string {
-	// Add title
-	title := titleStyle.Render("Configuration")
-
-	// Build config content
-	var configContent strings.Builder
-
-	// Theme selection
-	configContent.WriteString(subtitleStyle.Render("Theme Settings"))
-	configContent.WriteString("\n")
-	configContent.WriteString(fmt.Sprintf("Current Theme: %s\n", m.config.UI.Theme))
-	configContent.WriteString("Available Themes:\n")
-
-	// Get all theme names and sort them alphabetically
-	var themeNames []string
-	for name := range m.config.UI.Themes {
-		themeNames = append(themeNames, name)
-	}
-
-	// Sort theme names alphabetically
-	sort.Strings(themeNames)
-
-	// Display themes with consistent indentation
-	for _, name := range themeNames {
-		if name == m.config.UI.Theme {
-			configContent.WriteString(selectedItemStyle.Render(fmt.Sprintf("  > %s\n", name)))
-		} else {
-			configContent.WriteString(itemStyle.Render(fmt.Sprintf("    %s\n", name)))
-		}
-	}
+// resultsHeader renders the title above the results viewport
+func (m Model) resultsHeader() string {
+	return titleStyle.Render("Linter Results")
+}
 
-	// Join all components
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
-		title,
-		configContent.String(),
-	)
+// resizeViewport fits the results viewport between the header and the
+// footer of the results tab
+func (m *Model) resizeViewport() {
+	results := *m
+	results.activeTab = 2
+	header := lipgloss.JoinVertical(lipgloss.Left, renderLogo(), results.renderTabs(), results.resultsHeader())
+	footer := lipgloss.JoinVertical(lipgloss.Left, results.renderHelpBar(), results.renderStatusBar())
+
+	// newView keeps at least one blank line above the footer
+	chrome := lipgloss.Height(header) + lipgloss.Height(footer) + tabContentStyle.GetVerticalFrameSize() + 1
+	m.viewport.Width = m.width - 4
+	m.viewport.Height = max(m.height-chrome, 1)
 }
 
 // renderStatusBar renders the status bar
@@ -202,36 +183,29 @@ func (m Model) renderStatusBar() string {
 		statusText = fmt.Sprintf("%s Running linters...", m.spinner.View())
 	case StateResults:
 		statusText = "Linter results ready"
+		if m.err != "" {
+			statusText += " • " + m.err
+		}
 	default:
 		statusText = fmt.Sprintf("LazyLint - Theme: %s", m.config.UI.Theme)
+		if m.config.Profile != "" {
+			statusText += " - Profile: " + m.config.Profile
+		}
+	}
+
+	if m.watching {
+		statusText += " • Watching"
+	}
+	if m.status != "" {
+		statusText += " • " + m.status
 	}
 
 	return statusBarStyle.Width(m.width).Render(statusText)
 }
 
-// renderHelpBar renders the help bar with keyboard shortcuts
+// renderHelpBar renders the help bar with the keyboard shortcuts of the active tab
 func (m Model) renderHelpBar() string {
-	var shortcuts string
-
-	// Base shortcuts that are always available
-	baseShortcuts := "q: Quit • ?: Help • t: Change theme"
-
-	// Add tab-specific shortcuts
-	switch m.activeTab {
-	case 0: // Explorer tab
-		shortcuts = baseShortcuts + " • ↑/↓: Navigate • Space: Select file • Enter: Open • r: Run tools"
-	case 1: // Linters tab
-		shortcuts = baseShortcuts + " • ↑/↓: Navigate • Enter: Run selected linter"
-	case 2: // Results tab
-		shortcuts = baseShortcuts + " • ↑/↓: Scroll results"
-	case 3: // Config tab
-		shortcuts = baseShortcuts + " • ↑/↓: Navigate"
-	}
-
-	// Add tab navigation shortcuts
-	shortcuts += " • Tab: Next tab • Shift+Tab: Previous tab"
-
-	return helpBarStyle.Width(m.width).Render(shortcuts)
+	return helpBarStyle.Width(m.width).Render(m.help.View(m.keyMap()))
 }
 
 // newView renders the new UI layout
@@ -242,14 +216,30 @@ func (m Model) newView() string {
 		Width(m.width).
 		Height(m.height)
 
+	// The panes layout replaces the logo, the tabs and their content
+	if m.panesLayout() {
+		ui := lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.renderPanes(),
+			m.renderHelpBar(),
+			m.renderStatusBar(),
+		)
+		return backgroundStyle.Render(ui)
+	}
+
 	// Render the logo
 	logo := renderLogo()
 
 	// Render the tabs
 	tabs := m.renderTabs()
 
-	// Render the tab content
+	// Render the tab content, or an overlay on top of it
 	content := m.renderTabContent()
+	if m.palette.Active() {
+		content = m.palette.View()
+	} else if m.finder.Active() {
+		content = m.finder.View()
+	}
 
 	// Render the status bar
 	statusBar := m.renderStatusBar()
@@ -269,7 +259,7 @@ func (m Model) newView() string {
 	ui := lipgloss.JoinVertical(
 		lipgloss.Left,
 		mainContent,
-		lipgloss.NewStyle().Height(m.height - lipgloss.Height(mainContent) - 2).Render(""),
+		lipgloss.NewStyle().Height(m.height-lipgloss.Height(mainContent)-lipgloss.Height(helpBar)-1).Render(""),
 		helpBar,
 		statusBar,
 	)
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// watchInterval is how often watch mode checks the selected files for changes
+const watchInterval = time.Second
+
+// watchTickMsg triggers a check of the watched files. The generation tells
+// ticks of an earlier watch apart after toggling watch mode off and on.
+type watchTickMsg struct {
+	generation int
+}
+
+// toggleWatch starts or stops running the linters whenever a selected file is saved
+func (m *Model) toggleWatch() tea.Cmd {
+	m.watching = !m.watching
+	m.watchGeneration++
+	if !m.watching {
+		m.watchTimes = nil
+		return nil
+	}
+
+	m.watchTimes = modTimes(m.explorer.GetSelectedFiles())
+	return watchTick(m.watchGeneration)
+}
+
+// watchTick schedules the next check of the watched files
+func watchTick(generation int) tea.Cmd {
+	return tea.Tick(watchInterval, func(time.Time) tea.Msg {
+		return watchTickMsg{generation: generation}
+	})
+}
+
+// checkWatched runs the linters on the selected files when one of them changed
+// since the last check
+func (m *Model) checkWatched(msg watchTickMsg) tea.Cmd {
+	if !m.watching || msg.generation != m.watchGeneration {
+		return nil
+	}
+
+	// Check again once the running linters are done
+	if m.state == StateRunning {
+		return watchTick(m.watchGeneration)
+	}
+
+	files := m.explorer.GetSelectedFiles()
+	times := modTimes(files)
+	changed := false
+	for file, modified := range times {
+		if previous, ok := m.watchTimes[file]; ok && !modified.Equal(previous) {
+			changed = true
+			break
+		}
+	}
+	m.watchTimes = times
+
+	if !changed {
+		return watchTick(m.watchGeneration)
+	}
+	m.targets = files
+	return tea.Batch(watchTick(m.watchGeneration), m.startRun(m.activeLinters))
+}
+
+// modTimes returns the modification times of the files that exist
+func modTimes(files []string) map[string]time.Time {
+	times := make(map[string]time.Time, len(files))
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			times[file] = info.ModTime()
+		}
+	}
+	return times
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchRunsOnChangedFiles(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "App.php")
+	if err := os.WriteFile(file, []byte("<?php\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &Model{explorer: &Explorer{selectedFiles: map[string]bool{file: true}}}
+	if cmd := m.toggleWatch(); cmd == nil || !m.watching {
+		t.Fatal("toggleWatch() didn't start watching")
+	}
+
+	// Unchanged files don't start a run
+	m.checkWatched(watchTickMsg{generation: m.watchGeneration})
+	if len(m.targets) != 0 {
+		t.Fatalf("run started without changes: %v", m.targets)
+	}
+
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(file, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	// Ticks of an earlier watch are ignored
+	if cmd := m.checkWatched(watchTickMsg{generation: m.watchGeneration - 1}); cmd != nil || len(m.targets) != 0 {
+		t.Fatal("stale tick was handled")
+	}
+
+	m.checkWatched(watchTickMsg{generation: m.watchGeneration})
+	if len(m.targets) != 1 || m.targets[0] != file {
+		t.Errorf("targets = %v, want the changed file", m.targets)
+	}
+
+	m.toggleWatch()
+	if m.watching {
+		t.Error("toggleWatch() didn't stop watching")
+	}
+}
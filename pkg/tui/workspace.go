@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/crixuamg/pkg/workspace"
+)
+
+// SetWorkspace sets the sub-projects of the repository. Linters run from the
+// project that owns the linted files, and linters installed in a project
+// only become available.
+func (m *Model) SetWorkspace(ws *workspace.Workspace) {
+	m.workspace = ws
+	m.registry.SetDirs(ws.Dirs())
+	m.refreshLinters()
+	m.rebuildActions()
+}
+
+// renderWorkspace lists the sub-projects with the files they were found by
+func (m Model) renderWorkspace() string {
+	if m.workspace == nil || len(m.workspace.Projects) == 0 {
+		return ""
+	}
+
+	width := 0
+	for _, project := range m.workspace.Projects {
+		width = max(width, len(project.Dir))
+	}
+
+	var b strings.Builder
+	b.WriteString(subtitleStyle.Render("Workspace"))
+	for _, project := range m.workspace.Projects {
+		b.WriteString("\n")
+		b.WriteString(itemStyle.Render(fmt.Sprintf("  %-*s  ", width, project.Dir)))
+		b.WriteString(infoStyle.Render(strings.Join(project.Files, ", ")))
+	}
+	return b.String()
+}
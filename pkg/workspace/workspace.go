@@ -0,0 +1,187 @@
+package workspace
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Markers are the names of the files that make a directory the root of a
+// sub-project, matched with path.Match
+var Markers = []string{
+	"composer.json", "go.mod", "package.json",
+	"phpstan.neon", "phpstan.neon.dist", "phpstan.dist.neon",
+	".golangci.yml", ".golangci.yaml",
+	".eslintrc*", "eslint.config.*",
+}
+
+// linterMarkers are the markers that make a project the root of the runs of
+// a linter. Linters without markers run from the repository root.
+var linterMarkers = map[string][]string{
+	"golangci-lint": {"go.mod"},
+	"eslint":        {"package.json", ".eslintrc*", "eslint.config.*"},
+	"phpstan":       {"composer.json", "phpstan.neon", "phpstan.neon.dist", "phpstan.dist.neon"},
+	"phpcs":         {"composer.json"},
+}
+
+// skippedDirs are directories never scanned for projects
+var skippedDirs = map[string]bool{
+	"vendor":       true,
+	"node_modules": true,
+}
+
+// Project is a sub-project of a repository
+type Project struct {
+	// Dir is the directory of the project relative to the repository root,
+	// slash-separated and "." for the root itself
+	Dir string
+
+	// Files are the markers found in the directory, e.g. go.mod
+	Files []string
+}
+
+// Has reports whether the project holds a file matching one of the markers
+func (p Project) Has(markers ...string) bool {
+	for _, file := range p.Files {
+		for _, marker := range markers {
+			if ok, _ := path.Match(marker, file); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Workspace lists the sub-projects of a repository
+type Workspace struct {
+	Root     string
+	Projects []Project
+}
+
+// Detect scans the repository at root for sub-projects, skipping hidden and
+// dependency directories. The projects are sorted by directory.
+func Detect(root string) (*Workspace, error) {
+	ws := &Workspace{Root: root}
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Skip unreadable directories
+			if entry != nil && entry.IsDir() && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != root && (strings.HasPrefix(entry.Name(), ".") || skippedDirs[entry.Name()]) {
+			return filepath.SkipDir
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil
+		}
+
+		var files []string
+		for _, file := range entries {
+			if !file.IsDir() && isMarker(file.Name()) {
+				files = append(files, file.Name())
+			}
+		}
+		if len(files) > 0 {
+			rel, _ := filepath.Rel(root, path)
+			ws.Projects = append(ws.Projects, Project{Dir: filepath.ToSlash(rel), Files: files})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ws.Projects, func(i, j int) bool {
+		return ws.Projects[i].Dir < ws.Projects[j].Dir
+	})
+	return ws, nil
+}
+
+// isMarker reports whether the file name matches one of the markers
+func isMarker(name string) bool {
+	for _, marker := range Markers {
+		if ok, _ := path.Match(marker, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Dirs returns the absolute directories of the projects
+func (w *Workspace) Dirs() []string {
+	if w == nil {
+		return nil
+	}
+	dirs := make([]string, len(w.Projects))
+	for i, project := range w.Projects {
+		dirs[i] = filepath.Join(w.Root, filepath.FromSlash(project.Dir))
+	}
+	return dirs
+}
+
+// Owner returns the project a linter runs from for the file, given by its
+// slash-separated path relative to the repository root: the nearest project
+// above the file with a marker of the linter. Without one, or for linters
+// that always run from the repository root, it returns nil.
+func (w *Workspace) Owner(linter, rel string) *Project {
+	markers := linterMarkers[linter]
+	if w == nil || len(markers) == 0 {
+		return nil
+	}
+
+	var owner *Project
+	for i := range w.Projects {
+		project := &w.Projects[i]
+		if !project.Has(markers...) || !contains(project.Dir, rel) {
+			continue
+		}
+
+		// Projects are sorted, so nested projects come after their parents
+		owner = project
+	}
+	return owner
+}
+
+// Roots returns the projects a linter runs from when linting the whole
+// repository. Linters run from the repository root when it is a project of
+// theirs or when the linter has no projects, and from each of its
+// top-level projects otherwise, e.g. from each Go module.
+func (w *Workspace) Roots(linter string) []Project {
+	markers := linterMarkers[linter]
+	if w == nil || len(markers) == 0 {
+		return nil
+	}
+
+	var roots []Project
+	for _, project := range w.Projects {
+		if !project.Has(markers...) {
+			continue
+		}
+		if project.Dir == "." {
+			return nil
+		}
+
+		// Nested projects are linted with their parent
+		if len(roots) > 0 && contains(roots[len(roots)-1].Dir, project.Dir) {
+			continue
+		}
+		roots = append(roots, project)
+	}
+	return roots
+}
+
+// contains reports whether the slash-separated path is inside dir
+func contains(dir, rel string) bool {
+	return dir == "." || rel == dir || strings.HasPrefix(rel, dir+"/")
+}
@@ -0,0 +1,105 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// newRepo creates the given files below a new temporary directory
+func newRepo(t *testing.T, files ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, file := range files {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+	}
+	return root
+}
+
+func TestDetect(t *testing.T) {
+	root := newRepo(t,
+		"composer.json",
+		"services/legacy/composer.json",
+		"services/legacy/phpstan.neon",
+		"services/api/go.mod",
+		"services/api/.golangci.yml",
+		"frontend/admin/package.json",
+		"frontend/admin/.eslintrc.json",
+		"frontend/admin/node_modules/left-pad/package.json",
+		"vendor/acme/lib/composer.json",
+		".github/package.json",
+	)
+
+	ws, err := Detect(root)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+
+	want := []Project{
+		{Dir: ".", Files: []string{"composer.json"}},
+		{Dir: "frontend/admin", Files: []string{".eslintrc.json", "package.json"}},
+		{Dir: "services/api", Files: []string{".golangci.yml", "go.mod"}},
+		{Dir: "services/legacy", Files: []string{"composer.json", "phpstan.neon"}},
+	}
+	if !reflect.DeepEqual(ws.Projects, want) {
+		t.Errorf("Projects = %+v, want %+v", ws.Projects, want)
+	}
+}
+
+func TestOwner(t *testing.T) {
+	ws := &Workspace{Projects: []Project{
+		{Dir: ".", Files: []string{"composer.json"}},
+		{Dir: "services/api", Files: []string{"go.mod"}},
+		{Dir: "services/legacy", Files: []string{"phpstan.neon"}},
+	}}
+
+	tests := []struct {
+		linter, path string
+		want         string
+	}{
+		{"phpstan", "services/legacy/src/User.php", "services/legacy"},
+		{"phpstan", "services/legacy-v2/User.php", "."},
+		{"golangci-lint", "services/api/cmd/main.go", "services/api"},
+		{"golangci-lint", "tools/gen.go", ""},
+		{"php", "services/legacy/src/User.php", ""},
+	}
+	for _, tc := range tests {
+		got := ""
+		if project := ws.Owner(tc.linter, tc.path); project != nil {
+			got = project.Dir
+		}
+		if got != tc.want {
+			t.Errorf("Owner(%s, %s) = %q, want %q", tc.linter, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestRoots(t *testing.T) {
+	ws := &Workspace{Projects: []Project{
+		{Dir: ".", Files: []string{"composer.json"}},
+		{Dir: "services/api", Files: []string{"go.mod"}},
+		{Dir: "services/api/tools", Files: []string{"go.mod"}},
+		{Dir: "services/worker", Files: []string{"go.mod"}},
+	}}
+
+	// Go modules are linted one by one, nested ones with their parent
+	var dirs []string
+	for _, project := range ws.Roots("golangci-lint") {
+		dirs = append(dirs, project.Dir)
+	}
+	if want := []string{"services/api", "services/worker"}; !reflect.DeepEqual(dirs, want) {
+		t.Errorf("Roots = %v, want %v", dirs, want)
+	}
+
+	// A root project lints the whole repository at once
+	if roots := ws.Roots("phpcs"); roots != nil {
+		t.Errorf("Roots(phpcs) = %v, want none", roots)
+	}
+}